@@ -5,9 +5,12 @@ go 1.14
 require (
 	github.com/dgraph-io/ristretto v0.0.3 // indirect
 	github.com/ferranbt/fastssz v0.0.0-20201020132831-68dc48984fd3
+	github.com/ghodss/yaml v1.0.0
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/mock v1.4.4
 	github.com/golang/protobuf v1.4.2
+	github.com/golang/snappy v0.0.2-0.20200707131729-196ae77b8a26
+	github.com/google/uuid v1.1.1
 	github.com/herumi/bls-eth-go-binary v0.0.0-20200722032157-41fc56eba7b4
 	github.com/minio/highwayhash v1.0.0 // indirect
 	github.com/minio/sha256-simd v0.1.1
@@ -18,8 +21,12 @@ require (
 	github.com/prysmaticlabs/go-ssz v0.0.0-20200612203617-6d5c9aa213ae
 	github.com/prysmaticlabs/prysm v1.0.0-alpha.29
 	github.com/stretchr/testify v1.6.1
+	github.com/tyler-smith/go-bip39 v1.0.2
 	github.com/ulule/deepcopier v0.0.0-20200430083143-45decc6639b6
 	github.com/wealdtech/go-bytesutil v1.1.1
+	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
+	golang.org/x/sys v0.0.0-20200625212154-ddb9806d33ae
+	golang.org/x/text v0.3.3
 	google.golang.org/protobuf v1.25.0
 )
 
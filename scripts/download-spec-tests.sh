@@ -3,7 +3,8 @@
 VERSION=$1
 TEST_TYPE=$2
 BASE_PATH=./src/state_transition/spec_tests/.temp
-REPO_NAME=eth2.0-spec-tests
+# the spec tests repo was renamed to consensus-spec-tests from v1.1.8
+REPO_NAME=${3:-eth2.0-spec-tests}
 
 # Remove dir if it already exists
 rm -rf $REPO_NAME
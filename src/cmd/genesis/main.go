@@ -0,0 +1,112 @@
+// Command genesis writes and verifies devnet genesis files.
+//
+//  genesis interop -out <dir> -genesis-time <unix> -validators <count> [-config mainnet|minimal]
+//  genesis verify -file <genesis.ssz> -root <0x..> | -summary <genesis.yaml>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/genesis"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"os"
+	"path"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "interop":
+		err = interop(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: genesis interop|verify [flags]")
+	os.Exit(2)
+}
+
+func useConfig(name string) error {
+	switch name {
+	case "mainnet":
+		params.UseMainnetConfig()
+	case "minimal":
+		params.UseMinimalTestConfig()
+	default:
+		return fmt.Errorf("unknown config %s", name)
+	}
+	return nil
+}
+
+func interop(args []string) error {
+	fs := flag.NewFlagSet("interop", flag.ExitOnError)
+	out := fs.String("out", ".", "directory to write genesis.ssz and genesis.yaml into")
+	genesisTime := fs.Uint64("genesis-time", 0, "genesis time (unix seconds)")
+	validators := fs.Uint64("validators", 64, "number of interop validators")
+	config := fs.String("config", "mainnet", "chain config, mainnet or minimal")
+	_ = fs.Parse(args)
+
+	if err := useConfig(*config); err != nil {
+		return err
+	}
+	state, _, err := genesis.InteropGenesisState(*genesisTime, *validators)
+	if err != nil {
+		return err
+	}
+	summary, err := genesis.WriteGenesis(*out, state)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s, state root %s\n", path.Join(*out, genesis.SSZFileName), summary.StateRoot)
+	return nil
+}
+
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	file := fs.String("file", genesis.SSZFileName, "ssz encoded genesis state")
+	rootStr := fs.String("root", "", "expected state root")
+	summaryFile := fs.String("summary", "", "genesis summary to take the expected state root from")
+	config := fs.String("config", "mainnet", "chain config, mainnet or minimal")
+	_ = fs.Parse(args)
+
+	if err := useConfig(*config); err != nil {
+		return err
+	}
+	if *summaryFile != "" {
+		summary, err := genesis.LoadSummary(*summaryFile)
+		if err != nil {
+			return err
+		}
+		*rootStr = summary.StateRoot
+	}
+	if *rootStr == "" {
+		return fmt.Errorf("either -root or -summary is required")
+	}
+	root, err := genesis.DecodeRoot(*rootStr)
+	if err != nil {
+		return err
+	}
+
+	state, err := genesis.LoadGenesis(*file, root)
+	if err != nil {
+		return err
+	}
+	summary, err := genesis.NewSummary(state)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("ok, genesis time %d, %d validators, validators root %s\n", summary.GenesisTime, summary.ValidatorCount, summary.GenesisValidatorsRoot)
+	return nil
+}
@@ -0,0 +1,216 @@
+// Command operator produces the signed messages a validator operator submits.
+//
+//  operator deposit -mnemonic-file <file> [-index <i>] [-count <n>] [-amount <gwei>] [-withdrawal-credentials <0x..>] [-network-name <name>] [-out <dir>] [-config mainnet|minimal]
+//  operator exit -state <state.ssz> -keystore <file> -password-file <file> [-epoch <epoch>] [-out <file>] [-config mainnet|minimal]
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/deposit"
+	voluntaryexit "github.com/bloxapp/go-casper-ghost-SDK/src/exit"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keyderivation"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keystore"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "deposit":
+		err = depositData(os.Args[2:])
+	case "exit":
+		err = exit(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: operator deposit|exit [flags]")
+	os.Exit(2)
+}
+
+func useConfig(name string) error {
+	switch name {
+	case "mainnet":
+		params.UseMainnetConfig()
+	case "minimal":
+		params.UseMinimalTestConfig()
+	default:
+		return fmt.Errorf("unknown config %s", name)
+	}
+	return nil
+}
+
+func readSecret(file string) (string, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(byts)), nil
+}
+
+func depositData(args []string) error {
+	fs := flag.NewFlagSet("deposit", flag.ExitOnError)
+	mnemonicFile := fs.String("mnemonic-file", "", "file holding the validators' mnemonic")
+	index := fs.Uint64("index", 0, "first validator index (EIP-2334) to derive")
+	count := fs.Uint64("count", 1, "number of validators")
+	amount := fs.Uint64("amount", 0, "deposit amount in gwei, defaults to MAX_EFFECTIVE_BALANCE")
+	credentialsStr := fs.String("withdrawal-credentials", "", "0x prefixed withdrawal credentials, defaults to the derived withdrawal keys'")
+	networkName := fs.String("network-name", "", "network name written to the deposit data file")
+	out := fs.String("out", ".", "directory to write the deposit data file into")
+	config := fs.String("config", "mainnet", "chain config, mainnet or minimal")
+	_ = fs.Parse(args)
+
+	if err := useConfig(*config); err != nil {
+		return err
+	}
+	if *mnemonicFile == "" {
+		return fmt.Errorf("-mnemonic-file is required")
+	}
+	if *amount == 0 {
+		*amount = params.ChainConfig.MaxEffectiveBalance
+	}
+	var credentials []byte
+	if *credentialsStr != "" {
+		if !strings.HasPrefix(*credentialsStr, "0x") {
+			return fmt.Errorf("withdrawal credentials must be 0x prefixed")
+		}
+		var err error
+		if credentials, err = hex.DecodeString(strings.TrimPrefix(*credentialsStr, "0x")); err != nil {
+			return fmt.Errorf("withdrawal credentials: %s", err.Error())
+		}
+	}
+
+	mnemonic, err := readSecret(*mnemonicFile)
+	if err != nil {
+		return err
+	}
+	seed, err := keyderivation.SeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	keys, err := keyderivation.DeriveValidatorKeys(seed, *index, *count)
+	if err != nil {
+		return err
+	}
+	localSigner, err := signer.NewLocalSigner()
+	if err != nil {
+		return err
+	}
+
+	data := make([]*core.Deposit_DepositData, len(keys))
+	for i, k := range keys {
+		pubKey, err := localSigner.AddKey(k.SigningKey)
+		if err != nil {
+			return err
+		}
+		withdrawalCredentials := credentials
+		if withdrawalCredentials == nil {
+			if withdrawalCredentials, err = k.WithdrawalCredentials(); err != nil {
+				return err
+			}
+		}
+		if data[i], err = deposit.NewDepositData(localSigner, pubKey, withdrawalCredentials, *amount); err != nil {
+			return err
+		}
+	}
+
+	file := path.Join(*out, fmt.Sprintf("deposit_data-%d.json", time.Now().Unix()))
+	if err := deposit.WriteDataFile(file, data, *networkName); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s, %d deposits\n", file, len(data))
+	return nil
+}
+
+func exit(args []string) error {
+	fs := flag.NewFlagSet("exit", flag.ExitOnError)
+	stateFile := fs.String("state", "", "ssz encoded head state")
+	keystoreFile := fs.String("keystore", "", "the validator's EIP-2335 keystore")
+	passwordFile := fs.String("password-file", "", "file holding the keystore's password")
+	epoch := fs.Int64("epoch", -1, "exit epoch, defaults to the state's current epoch")
+	out := fs.String("out", "", "file to write the signed exit into, defaults to stdout")
+	config := fs.String("config", "mainnet", "chain config, mainnet or minimal")
+	_ = fs.Parse(args)
+
+	if err := useConfig(*config); err != nil {
+		return err
+	}
+	if *stateFile == "" || *keystoreFile == "" || *passwordFile == "" {
+		return fmt.Errorf("-state, -keystore and -password-file are required")
+	}
+
+	byts, err := ioutil.ReadFile(*stateFile)
+	if err != nil {
+		return err
+	}
+	state := &core.State{}
+	if err := state.UnmarshalSSZ(byts); err != nil {
+		return fmt.Errorf("state: %s", err.Error())
+	}
+	password, err := readSecret(*passwordFile)
+	if err != nil {
+		return err
+	}
+	ks, err := keystore.Load(*keystoreFile)
+	if err != nil {
+		return err
+	}
+	sk, err := ks.Decrypt(password)
+	if err != nil {
+		return err
+	}
+	localSigner, err := signer.NewLocalSigner()
+	if err != nil {
+		return err
+	}
+	pubKey, err := localSigner.AddKey(sk)
+	if err != nil {
+		return err
+	}
+	validatorIndex, err := shared.ValidatorIndexByPubkey(state, pubKey)
+	if err != nil {
+		return err
+	}
+
+	exitEpoch := shared.GetCurrentEpoch(state)
+	if *epoch >= 0 {
+		exitEpoch = uint64(*epoch)
+	}
+	signed, err := voluntaryexit.NewSignedVoluntaryExit(state, validatorIndex, exitEpoch, localSigner)
+	if err != nil {
+		return err
+	}
+	byts, err = voluntaryexit.MarshalJSON(signed)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		fmt.Println(string(byts))
+		return nil
+	}
+	if err := ioutil.WriteFile(*out, byts, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s, exit of validator %d at epoch %d\n", *out, validatorIndex, exitEpoch)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Command remotesigner serves Web3Signer compatible signing requests from EIP-2335 keystores.
+//
+//  remotesigner -keystores <dir> -password-file <file> [-slashing-protection <dir>] [-listen <addr>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keystore"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/remotesigner"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/slashingprotection"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func main() {
+	keystores := flag.String("keystores", "", "directory of the keystores to sign with")
+	passwordFile := flag.String("password-file", "", "file holding the keystores' password")
+	protectionDir := flag.String("slashing-protection", "slashing-protection", "slashing protection directory, empty to sign without")
+	listen := flag.String("listen", "localhost:9000", "address to listen on")
+	flag.Parse()
+
+	if err := run(*keystores, *passwordFile, *protectionDir, *listen); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(keystores string, passwordFile string, protectionDir string, listen string) error {
+	if keystores == "" || passwordFile == "" {
+		return fmt.Errorf("-keystores and -password-file are required")
+	}
+	password, err := ioutil.ReadFile(passwordFile)
+	if err != nil {
+		return err
+	}
+
+	manager := keystore.NewManager()
+	if err := manager.LoadDir(keystores); err != nil {
+		return err
+	}
+	if err := manager.UnlockAll(strings.TrimRight(string(password), "\r\n")); err != nil {
+		return err
+	}
+	var protection *slashingprotection.Store
+	if protectionDir != "" {
+		if protection, err = slashingprotection.NewStore(protectionDir); err != nil {
+			return err
+		}
+		defer protection.Close()
+	}
+
+	fmt.Printf("serving %d keys on %s\n", len(manager.PubKeys()), listen)
+	return http.ListenAndServe(listen, remotesigner.NewServer(manager, protection))
+}
@@ -367,51 +367,182 @@ func (m *Checkpoint) GetRoot() []byte {
 	return nil
 }
 
+type AggregateAndProof struct {
+	// Index of the validator that aggregated the attestation.
+	AggregatorIndex uint64 `protobuf:"varint,1,opt,name=aggregator_index,json=aggregatorIndex,proto3" json:"aggregator_index,omitempty"`
+	// The aggregated attestation that was submitted.
+	Aggregate *Attestation `protobuf:"bytes,2,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	// 96 byte selection proof, the aggregator's signature of the attestation slot.
+	SelectionProof       []byte   `protobuf:"bytes,3,opt,name=selection_proof,json=selectionProof,proto3" json:"selection_proof,omitempty" ssz-size:"96"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AggregateAndProof) Reset()         { *m = AggregateAndProof{} }
+func (m *AggregateAndProof) String() string { return proto.CompactTextString(m) }
+func (*AggregateAndProof) ProtoMessage()    {}
+func (*AggregateAndProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dd514a9c0145b2e7, []int{5}
+}
+func (m *AggregateAndProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AggregateAndProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AggregateAndProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AggregateAndProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateAndProof.Merge(m, src)
+}
+func (m *AggregateAndProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *AggregateAndProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateAndProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateAndProof proto.InternalMessageInfo
+
+func (m *AggregateAndProof) GetAggregatorIndex() uint64 {
+	if m != nil {
+		return m.AggregatorIndex
+	}
+	return 0
+}
+
+func (m *AggregateAndProof) GetAggregate() *Attestation {
+	if m != nil {
+		return m.Aggregate
+	}
+	return nil
+}
+
+func (m *AggregateAndProof) GetSelectionProof() []byte {
+	if m != nil {
+		return m.SelectionProof
+	}
+	return nil
+}
+
+type SignedAggregateAndProof struct {
+	Message *AggregateAndProof `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// 96 byte BLS signature of the aggregator over the message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty" ssz-size:"96"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedAggregateAndProof) Reset()         { *m = SignedAggregateAndProof{} }
+func (m *SignedAggregateAndProof) String() string { return proto.CompactTextString(m) }
+func (*SignedAggregateAndProof) ProtoMessage()    {}
+func (*SignedAggregateAndProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dd514a9c0145b2e7, []int{6}
+}
+func (m *SignedAggregateAndProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignedAggregateAndProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignedAggregateAndProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignedAggregateAndProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedAggregateAndProof.Merge(m, src)
+}
+func (m *SignedAggregateAndProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignedAggregateAndProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedAggregateAndProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedAggregateAndProof proto.InternalMessageInfo
+
+func (m *SignedAggregateAndProof) GetMessage() *AggregateAndProof {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *SignedAggregateAndProof) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PendingAttestation)(nil), "core.PendingAttestation")
 	proto.RegisterType((*Attestation)(nil), "core.Attestation")
 	proto.RegisterType((*AttestationData)(nil), "core.AttestationData")
 	proto.RegisterType((*IndexedAttestation)(nil), "core.IndexedAttestation")
 	proto.RegisterType((*Checkpoint)(nil), "core.Checkpoint")
+	proto.RegisterType((*AggregateAndProof)(nil), "core.AggregateAndProof")
+	proto.RegisterType((*SignedAggregateAndProof)(nil), "core.SignedAggregateAndProof")
 }
 
 func init() { proto.RegisterFile("src/core/attestation.proto", fileDescriptor_dd514a9c0145b2e7) }
 
 var fileDescriptor_dd514a9c0145b2e7 = []byte{
-	// 528 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x53, 0x4f, 0x8b, 0xd3, 0x4e,
-	0x18, 0xfe, 0xa5, 0x9b, 0x2d, 0xfc, 0xa6, 0xbb, 0xfd, 0x33, 0x28, 0x84, 0x15, 0xda, 0x12, 0x58,
-	0xac, 0x60, 0x13, 0xe9, 0x8a, 0xe8, 0x8a, 0x2c, 0xc6, 0xbd, 0xf4, 0xa4, 0xe4, 0xe8, 0xa5, 0x4c,
-	0x26, 0xb3, 0xd3, 0x61, 0x93, 0x4c, 0x98, 0x79, 0x0b, 0xbb, 0xfd, 0x0a, 0x7e, 0x01, 0xbf, 0x86,
-	0xdf, 0xc2, 0xa3, 0x47, 0x4f, 0x45, 0xeb, 0x37, 0xe8, 0xd1, 0x93, 0xcc, 0xa4, 0xb6, 0x45, 0xed,
-	0x51, 0xbc, 0xcd, 0xfb, 0xbc, 0x4f, 0x26, 0xcf, 0x1f, 0x06, 0x9d, 0x68, 0x45, 0x43, 0x2a, 0x15,
-	0x0b, 0x09, 0x00, 0xd3, 0x40, 0x40, 0xc8, 0x22, 0x28, 0x95, 0x04, 0x89, 0x5d, 0x83, 0x9f, 0x0c,
-	0xb9, 0x80, 0xe9, 0x2c, 0x09, 0xa8, 0xcc, 0x43, 0x2e, 0xb9, 0x0c, 0xed, 0x32, 0x99, 0x5d, 0xd9,
-	0xc9, 0x0e, 0xf6, 0x54, 0x7d, 0xe4, 0xbf, 0xab, 0x21, 0xfc, 0x86, 0x15, 0xa9, 0x28, 0xf8, 0xcb,
-	0xed, 0x8d, 0x78, 0x8e, 0xda, 0x84, 0x73, 0xc5, 0xb8, 0x1d, 0x27, 0x89, 0x00, 0xed, 0x39, 0x7d,
-	0x67, 0x70, 0x14, 0xbd, 0x5e, 0x2d, 0x7a, 0xf7, 0xb4, 0x9e, 0x0f, 0xb5, 0x98, 0xb3, 0x73, 0xff,
-	0xc2, 0xef, 0x9b, 0x21, 0x27, 0x37, 0xe7, 0xfe, 0xe8, 0xd1, 0xe3, 0xa7, 0xfe, 0xf7, 0x45, 0xef,
-	0xe1, 0x8e, 0x84, 0x52, 0xdd, 0xea, 0x9c, 0x80, 0xa0, 0x19, 0x49, 0x74, 0xc8, 0xe5, 0x30, 0x11,
-	0x70, 0x25, 0x58, 0x96, 0x06, 0x91, 0x80, 0x4c, 0x68, 0x88, 0x5b, 0x3b, 0x3f, 0x8a, 0x04, 0x68,
-	0xfc, 0x00, 0xb9, 0x29, 0x01, 0xe2, 0xd5, 0xfa, 0xce, 0xa0, 0x31, 0xba, 0x1b, 0x18, 0x5b, 0xc1,
-	0x8e, 0xb8, 0x4b, 0x02, 0x24, 0xb6, 0x14, 0x7c, 0x1f, 0xb5, 0x44, 0x41, 0xb3, 0x99, 0x36, 0x22,
-	0x53, 0x96, 0x91, 0x5b, 0xef, 0xa0, 0xef, 0x0c, 0xdc, 0xb8, 0xb9, 0x81, 0x2f, 0x0d, 0x8a, 0x4f,
-	0x51, 0xb3, 0x54, 0xb2, 0x94, 0x9a, 0xa9, 0x89, 0x28, 0x52, 0x76, 0xe3, 0xb9, 0x96, 0x77, 0xfc,
-	0x13, 0x1d, 0x1b, 0xd0, 0xff, 0xec, 0xa0, 0xc6, 0x6e, 0x0c, 0xf9, 0xde, 0x18, 0xa2, 0xd5, 0xa2,
-	0xd7, 0xfc, 0x87, 0xce, 0x43, 0xf4, 0xbf, 0x16, 0xbc, 0x20, 0x30, 0x53, 0xcc, 0x7a, 0x3e, 0x8a,
-	0x3a, 0xab, 0x45, 0xef, 0x78, 0xdb, 0xcc, 0xb3, 0x27, 0x7e, 0xbc, 0xe5, 0xf8, 0x5f, 0x1d, 0xd4,
-	0xfa, 0xe5, 0x2a, 0x8c, 0x91, 0xab, 0x33, 0x09, 0xd6, 0x92, 0x1b, 0xdb, 0xb3, 0x89, 0x94, 0xca,
-	0x3c, 0x17, 0x00, 0x8c, 0xad, 0xa3, 0xaa, 0x55, 0x91, 0x6e, 0x60, 0x9b, 0x15, 0x7e, 0x81, 0x3a,
-	0x09, 0x23, 0xd4, 0xc4, 0x92, 0x49, 0x7a, 0x3d, 0x51, 0x52, 0xc2, 0x9f, 0x95, 0x9c, 0x8d, 0xfc,
-	0xb8, 0x55, 0x71, 0x23, 0x43, 0x8d, 0xa5, 0x04, 0x3c, 0x40, 0x75, 0x2d, 0x67, 0x8a, 0x32, 0xdb,
-	0x44, 0x63, 0xd4, 0xae, 0xdc, 0xbe, 0x9a, 0x32, 0x7a, 0x5d, 0x4a, 0x51, 0x40, 0xbc, 0xde, 0x1b,
-	0x26, 0x10, 0xc5, 0x19, 0x78, 0x87, 0xfb, 0x98, 0xd5, 0xde, 0xff, 0xe0, 0x20, 0x6c, 0xc5, 0xb1,
-	0x74, 0xb7, 0xc5, 0x0b, 0xd4, 0xa9, 0x5e, 0x8b, 0x28, 0xb8, 0xb1, 0x24, 0x28, 0x33, 0x35, 0x1e,
-	0x0c, 0xdc, 0x08, 0xff, 0x5e, 0x63, 0xdc, 0xde, 0x90, 0xc7, 0x15, 0xf7, 0xaf, 0xf6, 0x32, 0x46,
-	0x68, 0xeb, 0x04, 0xdf, 0x41, 0x87, 0xac, 0x94, 0x74, 0xba, 0xae, 0xa4, 0x1a, 0xf0, 0x29, 0x72,
-	0x6d, 0xba, 0xb5, 0x7d, 0xe9, 0xda, 0x75, 0xe4, 0x7d, 0x5c, 0x76, 0x9d, 0x4f, 0xcb, 0xae, 0xf3,
-	0x65, 0xd9, 0x75, 0xde, 0x7f, 0xeb, 0xfe, 0xf7, 0xb6, 0x1e, 0x3c, 0x37, 0x5a, 0x93, 0xba, 0x7d,
-	0xec, 0x67, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd2, 0x3c, 0xac, 0xe3, 0x3f, 0x04, 0x00, 0x00,
+	// 625 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x54, 0xcf, 0x6e, 0xd3, 0x3e,
+	0x1c, 0xff, 0xa5, 0xcb, 0xf6, 0xd3, 0xdc, 0xad, 0x5d, 0x2d, 0xd0, 0xaa, 0x21, 0xb5, 0x55, 0xa4,
+	0x89, 0x4e, 0x62, 0x0d, 0x74, 0x08, 0xc1, 0x10, 0x9a, 0x16, 0x76, 0xd9, 0x89, 0x29, 0xdc, 0xb8,
+	0x4c, 0x8e, 0xf3, 0x9d, 0x67, 0x2d, 0x89, 0x23, 0xdb, 0x95, 0xb6, 0x49, 0x3c, 0x01, 0x2f, 0xc0,
+	0x3b, 0x70, 0xe2, 0x2d, 0x38, 0x72, 0xe4, 0x54, 0xc1, 0x78, 0x83, 0x1d, 0x39, 0x21, 0x3b, 0x69,
+	0x53, 0x36, 0x2a, 0x4e, 0x88, 0x5b, 0xbe, 0x1f, 0x7f, 0x6a, 0x7f, 0xfe, 0xd8, 0x45, 0x1b, 0x4a,
+	0x52, 0x9f, 0x0a, 0x09, 0x3e, 0xd1, 0x1a, 0x94, 0x26, 0x9a, 0x8b, 0x6c, 0x90, 0x4b, 0xa1, 0x05,
+	0x76, 0x0d, 0xbe, 0xb1, 0xcd, 0xb8, 0x3e, 0x1d, 0x45, 0x03, 0x2a, 0x52, 0x9f, 0x09, 0x26, 0x7c,
+	0xbb, 0x18, 0x8d, 0x4e, 0xec, 0x64, 0x07, 0xfb, 0x55, 0xfc, 0xc8, 0x7b, 0x57, 0x43, 0xf8, 0x08,
+	0xb2, 0x98, 0x67, 0x6c, 0xbf, 0xda, 0x11, 0x5f, 0xa2, 0x35, 0xc2, 0x98, 0x04, 0x66, 0xc7, 0xe3,
+	0x88, 0x6b, 0xd5, 0x76, 0x7a, 0x4e, 0x7f, 0x25, 0x78, 0x75, 0x3d, 0xee, 0xde, 0x53, 0xea, 0x72,
+	0x5b, 0xf1, 0x4b, 0xd8, 0xf5, 0xf6, 0xbc, 0x9e, 0x19, 0x52, 0x72, 0xbe, 0xeb, 0x0d, 0x1f, 0x3e,
+	0x7e, 0xea, 0xfd, 0x18, 0x77, 0x1f, 0xcc, 0x48, 0xc8, 0xe5, 0x85, 0x4a, 0x89, 0xe6, 0x34, 0x21,
+	0x91, 0xf2, 0x99, 0xd8, 0x8e, 0xb8, 0x3e, 0xe1, 0x90, 0xc4, 0x83, 0x80, 0xeb, 0x84, 0x2b, 0x1d,
+	0x36, 0x67, 0x0e, 0x0a, 0xb8, 0x56, 0x78, 0x0b, 0xb9, 0x31, 0xd1, 0xa4, 0x5d, 0xeb, 0x39, 0xfd,
+	0xfa, 0xf0, 0xee, 0xc0, 0xd8, 0x1a, 0xcc, 0x88, 0x3b, 0x20, 0x9a, 0x84, 0x96, 0x82, 0xef, 0xa3,
+	0x26, 0xcf, 0x68, 0x32, 0x52, 0x46, 0x64, 0x0c, 0x09, 0xb9, 0x68, 0x2f, 0xf4, 0x9c, 0xbe, 0x1b,
+	0x36, 0xa6, 0xf0, 0x81, 0x41, 0xf1, 0x26, 0x6a, 0xe4, 0x52, 0xe4, 0x42, 0x81, 0x3c, 0xe6, 0x59,
+	0x0c, 0xe7, 0x6d, 0xd7, 0xf2, 0x56, 0x27, 0xe8, 0xa1, 0x01, 0xbd, 0x2f, 0x0e, 0xaa, 0xcf, 0xc6,
+	0x90, 0xce, 0x8d, 0x21, 0xb8, 0x1e, 0x77, 0x1b, 0xff, 0xd0, 0xb9, 0x8f, 0x96, 0x15, 0x67, 0x19,
+	0xd1, 0x23, 0x09, 0xd6, 0xf3, 0x4a, 0xd0, 0xba, 0x1e, 0x77, 0x57, 0xab, 0x66, 0x9e, 0x3d, 0xf1,
+	0xc2, 0x8a, 0xe3, 0x7d, 0x73, 0x50, 0xf3, 0xc6, 0x56, 0x18, 0x23, 0x57, 0x25, 0x42, 0x5b, 0x4b,
+	0x6e, 0x68, 0xbf, 0x4d, 0xa4, 0x54, 0xa4, 0x29, 0xd7, 0x1a, 0xa0, 0x8c, 0xaa, 0x56, 0x44, 0x3a,
+	0x85, 0x6d, 0x56, 0xf8, 0x05, 0x6a, 0x45, 0x40, 0xa8, 0x89, 0x25, 0x11, 0xf4, 0xec, 0x58, 0x0a,
+	0xa1, 0x7f, 0xaf, 0x64, 0x67, 0xe8, 0x85, 0xcd, 0x82, 0x1b, 0x18, 0x6a, 0x28, 0x84, 0xc6, 0x7d,
+	0xb4, 0xa4, 0xc4, 0x48, 0x52, 0xb0, 0x4d, 0xd4, 0x87, 0x6b, 0x85, 0xdb, 0x97, 0xa7, 0x40, 0xcf,
+	0x72, 0xc1, 0x33, 0x1d, 0x96, 0xeb, 0x86, 0xa9, 0x89, 0x64, 0xa0, 0xdb, 0x8b, 0xf3, 0x98, 0xc5,
+	0xba, 0xf7, 0xd1, 0x41, 0xd8, 0x8a, 0x83, 0x78, 0xb6, 0xc5, 0x3d, 0xd4, 0x2a, 0x5e, 0x0b, 0xcf,
+	0x98, 0xb1, 0xc4, 0x29, 0x98, 0x1a, 0x17, 0xfa, 0x6e, 0x80, 0x6f, 0xd7, 0x18, 0xae, 0x4d, 0xc9,
+	0x87, 0x05, 0xf7, 0xaf, 0xf6, 0x72, 0x88, 0x50, 0xe5, 0x04, 0xdf, 0x41, 0x8b, 0x90, 0x0b, 0x7a,
+	0x5a, 0x56, 0x52, 0x0c, 0x78, 0x13, 0xb9, 0x36, 0xdd, 0xda, 0xbc, 0x74, 0xed, 0xb2, 0xf7, 0xc1,
+	0x41, 0xad, 0xfd, 0xf2, 0x4a, 0xc1, 0x7e, 0x16, 0x1f, 0x49, 0x21, 0x4e, 0xf0, 0x56, 0x75, 0x87,
+	0xc5, 0xe4, 0xf2, 0x17, 0xbb, 0x37, 0x2b, 0xbc, 0xa8, 0xd4, 0x47, 0xcb, 0x13, 0x08, 0x4a, 0xb3,
+	0xad, 0x5b, 0x66, 0xc3, 0x8a, 0x83, 0x77, 0x51, 0x53, 0x41, 0x02, 0xd4, 0xbe, 0x8e, 0xdc, 0x1c,
+	0x37, 0xdf, 0x73, 0x63, 0xca, 0xb4, 0xba, 0xbc, 0xb7, 0x68, 0xfd, 0x35, 0x67, 0x19, 0xc4, 0xb7,
+	0x25, 0x3f, 0x42, 0xff, 0xa7, 0xa0, 0x14, 0x61, 0x60, 0x95, 0xd6, 0x87, 0xeb, 0xa5, 0x8a, 0x9b,
+	0xcc, 0x70, 0xc2, 0xfb, 0x35, 0xf7, 0xda, 0x9f, 0x73, 0x0f, 0xda, 0x9f, 0xae, 0x3a, 0xce, 0xe7,
+	0xab, 0x8e, 0xf3, 0xf5, 0xaa, 0xe3, 0xbc, 0xff, 0xde, 0xf9, 0xef, 0xcd, 0xd2, 0xe0, 0xb9, 0x39,
+	0x25, 0x5a, 0xb2, 0xff, 0x8c, 0x3b, 0x3f, 0x07, 0x00, 0x62, 0x49, 0x8c, 0x7e, 0x6c, 0x05, 0x00,
+	0x00,
 }
 
 func (m *PendingAttestation) Marshal() (dAtA []byte, err error) {
@@ -694,6 +825,103 @@ func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AggregateAndProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AggregateAndProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AggregateAndProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SelectionProof) > 0 {
+		i -= len(m.SelectionProof)
+		copy(dAtA[i:], m.SelectionProof)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.SelectionProof)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Aggregate != nil {
+		{
+			size, err := m.Aggregate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.AggregatorIndex != 0 {
+		i = encodeVarintAttestation(dAtA, i, uint64(m.AggregatorIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignedAggregateAndProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedAggregateAndProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignedAggregateAndProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Message != nil {
+		{
+			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintAttestation(dAtA []byte, offset int, v uint64) int {
 	offset -= sovAttestation(v)
 	base := offset
@@ -831,6 +1059,49 @@ func (m *Checkpoint) Size() (n int) {
 	return n
 }
 
+func (m *AggregateAndProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AggregatorIndex != 0 {
+		n += 1 + sovAttestation(uint64(m.AggregatorIndex))
+	}
+	if m.Aggregate != nil {
+		l = m.Aggregate.Size()
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.SelectionProof)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignedAggregateAndProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovAttestation(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1662,6 +1933,273 @@ func (m *Checkpoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AggregateAndProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AggregateAndProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AggregateAndProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatorIndex", wireType)
+			}
+			m.AggregatorIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AggregatorIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Aggregate == nil {
+				m.Aggregate = &Attestation{}
+			}
+			if err := m.Aggregate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectionProof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelectionProof = append(m.SelectionProof[:0], dAtA[iNdEx:postIndex]...)
+			if m.SelectionProof == nil {
+				m.SelectionProof = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedAggregateAndProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedAggregateAndProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedAggregateAndProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &AggregateAndProof{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipAttestation(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -35,6 +35,12 @@ type ChainConfig struct {
 	MinEpochsToInactivityPenalty     uint64 `protobuf:"varint,106,opt,name=MinEpochsToInactivityPenalty,proto3" json:"MinEpochsToInactivityPenalty,omitempty"`
 	EpochsPerETH1VotingPeriod        uint64 `protobuf:"varint,107,opt,name=EpochsPerETH1VotingPeriod,proto3" json:"EpochsPerETH1VotingPeriod,omitempty"`
 	ShardCommitteePeriod             uint64 `protobuf:"varint,108,opt,name=ShardCommitteePeriod,proto3" json:"ShardCommitteePeriod,omitempty"`
+	SecondsPerSlot                   uint64 `protobuf:"varint,109,opt,name=SecondsPerSlot,proto3" json:"SecondsPerSlot,omitempty"`
+	SafeSlotsToUpdateJustified       uint64 `protobuf:"varint,110,opt,name=SafeSlotsToUpdateJustified,proto3" json:"SafeSlotsToUpdateJustified,omitempty"`
+	IntervalsPerSlot                 uint64 `protobuf:"varint,111,opt,name=IntervalsPerSlot,proto3" json:"IntervalsPerSlot,omitempty"`
+	GenesisDelay                     uint64 `protobuf:"varint,112,opt,name=GenesisDelay,proto3" json:"GenesisDelay,omitempty"`
+	SecondsPerETH1Block              uint64 `protobuf:"varint,113,opt,name=SecondsPerETH1Block,proto3" json:"SecondsPerETH1Block,omitempty"`
+	ETH1FollowDistance               uint64 `protobuf:"varint,114,opt,name=ETH1FollowDistance,proto3" json:"ETH1FollowDistance,omitempty"`
 	// Misc
 	MaxCommitteesPerSlot           uint64 `protobuf:"varint,200,opt,name=MaxCommitteesPerSlot,proto3" json:"MaxCommitteesPerSlot,omitempty"`
 	TargetCommitteeSize            uint64 `protobuf:"varint,201,opt,name=TargetCommitteeSize,proto3" json:"TargetCommitteeSize,omitempty"`
@@ -49,6 +55,8 @@ type ChainConfig struct {
 	HysteresisDownwardMultiplier   uint64 `protobuf:"varint,210,opt,name=HysteresisDownwardMultiplier,proto3" json:"HysteresisDownwardMultiplier,omitempty"`
 	HysteresisUpwardMultiplier     uint64 `protobuf:"varint,211,opt,name=HysteresisUpwardMultiplier,proto3" json:"HysteresisUpwardMultiplier,omitempty"`
 	ShuffleRoundCount              uint64 `protobuf:"varint,212,opt,name=ShuffleRoundCount,proto3" json:"ShuffleRoundCount,omitempty"`
+	ProposerScoreBoost             uint64 `protobuf:"varint,213,opt,name=ProposerScoreBoost,proto3" json:"ProposerScoreBoost,omitempty"`
+	TargetAggregatorsPerCommittee  uint64 `protobuf:"varint,214,opt,name=TargetAggregatorsPerCommittee,proto3" json:"TargetAggregatorsPerCommittee,omitempty"`
 	// constants
 	FarFutureEpoch           uint64 `protobuf:"varint,300,opt,name=FarFutureEpoch,proto3" json:"FarFutureEpoch,omitempty"`
 	ZeroHash                 []byte `protobuf:"bytes,301,opt,name=ZeroHash,proto3" json:"ZeroHash,omitempty"`
@@ -56,6 +64,7 @@ type ChainConfig struct {
 	GenesisEpoch             uint64 `protobuf:"varint,303,opt,name=GenesisEpoch,proto3" json:"GenesisEpoch,omitempty"`
 	BaseRewardsPerEpoch      uint64 `protobuf:"varint,304,opt,name=BaseRewardsPerEpoch,proto3" json:"BaseRewardsPerEpoch,omitempty"`
 	DepositContractTreeDepth uint64 `protobuf:"varint,305,opt,name=DepositContractTreeDepth,proto3" json:"DepositContractTreeDepth,omitempty"`
+	BLSWithdrawalPrefix      []byte `protobuf:"bytes,306,opt,name=BLSWithdrawalPrefix,proto3" json:"BLSWithdrawalPrefix,omitempty"`
 	// state list lengths
 	EpochsPerHistoricalVector uint64 `protobuf:"varint,400,opt,name=EpochsPerHistoricalVector,proto3" json:"EpochsPerHistoricalVector,omitempty"`
 	EpochsPerSlashingVector   uint64 `protobuf:"varint,401,opt,name=EpochsPerSlashingVector,proto3" json:"EpochsPerSlashingVector,omitempty"`
@@ -195,6 +204,48 @@ func (m *ChainConfig) GetShardCommitteePeriod() uint64 {
 	return 0
 }
 
+func (m *ChainConfig) GetSecondsPerSlot() uint64 {
+	if m != nil {
+		return m.SecondsPerSlot
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetSafeSlotsToUpdateJustified() uint64 {
+	if m != nil {
+		return m.SafeSlotsToUpdateJustified
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetIntervalsPerSlot() uint64 {
+	if m != nil {
+		return m.IntervalsPerSlot
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetGenesisDelay() uint64 {
+	if m != nil {
+		return m.GenesisDelay
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetSecondsPerETH1Block() uint64 {
+	if m != nil {
+		return m.SecondsPerETH1Block
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetETH1FollowDistance() uint64 {
+	if m != nil {
+		return m.ETH1FollowDistance
+	}
+	return 0
+}
+
 func (m *ChainConfig) GetMaxCommitteesPerSlot() uint64 {
 	if m != nil {
 		return m.MaxCommitteesPerSlot
@@ -286,6 +337,20 @@ func (m *ChainConfig) GetShuffleRoundCount() uint64 {
 	return 0
 }
 
+func (m *ChainConfig) GetProposerScoreBoost() uint64 {
+	if m != nil {
+		return m.ProposerScoreBoost
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetTargetAggregatorsPerCommittee() uint64 {
+	if m != nil {
+		return m.TargetAggregatorsPerCommittee
+	}
+	return 0
+}
+
 func (m *ChainConfig) GetFarFutureEpoch() uint64 {
 	if m != nil {
 		return m.FarFutureEpoch
@@ -328,6 +393,13 @@ func (m *ChainConfig) GetDepositContractTreeDepth() uint64 {
 	return 0
 }
 
+func (m *ChainConfig) GetBLSWithdrawalPrefix() []byte {
+	if m != nil {
+		return m.BLSWithdrawalPrefix
+	}
+	return nil
+}
+
 func (m *ChainConfig) GetEpochsPerHistoricalVector() uint64 {
 	if m != nil {
 		return m.EpochsPerHistoricalVector
@@ -517,77 +589,86 @@ func init() {
 func init() { proto.RegisterFile("src/core/config.proto", fileDescriptor_d0189c35229c86e3) }
 
 var fileDescriptor_d0189c35229c86e3 = []byte{
-	// 1112 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x49, 0x8f, 0x1b, 0x45,
-	0x14, 0xc6, 0x61, 0x32, 0x82, 0xca, 0x00, 0xa1, 0x48, 0x86, 0x0a, 0x84, 0xd1, 0x10, 0x84, 0x08,
-	0x20, 0x66, 0x94, 0x44, 0x80, 0x50, 0x40, 0x68, 0xc6, 0xf6, 0x2c, 0x10, 0x4b, 0xc6, 0x36, 0x8e,
-	0x94, 0x5b, 0x4d, 0xf7, 0xb3, 0xbb, 0x32, 0xed, 0x2a, 0xab, 0xaa, 0x9a, 0xb1, 0xf9, 0x15, 0x2c,
-	0x17, 0x8e, 0x6c, 0x47, 0xd6, 0x3b, 0x70, 0xe2, 0x10, 0xf6, 0xb0, 0x1c, 0xd8, 0x85, 0x86, 0xbf,
-	0xc0, 0x7e, 0x42, 0xd5, 0xaf, 0xdd, 0x3d, 0xb6, 0xbb, 0x9d, 0x63, 0x7f, 0xcb, 0xab, 0xea, 0xf7,
-	0xea, 0xbd, 0x2a, 0x72, 0xdc, 0x68, 0x6f, 0xd5, 0x53, 0x1a, 0x56, 0x3d, 0x25, 0x3b, 0xa2, 0xbb,
-	0xd2, 0xd7, 0xca, 0x2a, 0x3a, 0xe7, 0xa0, 0x53, 0x1f, 0x2f, 0x92, 0x23, 0xe5, 0x80, 0x0b, 0x59,
-	0x8e, 0x39, 0xba, 0x42, 0xe8, 0x26, 0x48, 0x30, 0xc2, 0x6c, 0x28, 0xbd, 0xdb, 0x06, 0x6d, 0x84,
-	0x92, 0xac, 0xb4, 0x5c, 0x3a, 0xbd, 0xd0, 0xc8, 0x61, 0xe8, 0x29, 0xb2, 0xd0, 0x0c, 0x95, 0x35,
-	0xdb, 0xb2, 0xda, 0x57, 0x5e, 0xc0, 0xfc, 0xe5, 0xd2, 0xe9, 0xb9, 0xc6, 0x18, 0x46, 0xd7, 0xc9,
-	0xc9, 0x9a, 0x90, 0x6b, 0xd6, 0x82, 0xb1, 0xdc, 0x0a, 0x25, 0xb7, 0xa5, 0x17, 0x46, 0xce, 0x5e,
-	0x81, 0x90, 0x0f, 0x19, 0xc4, 0x9e, 0x99, 0x1a, 0xfa, 0x00, 0x39, 0x5a, 0xe3, 0x83, 0x26, 0x80,
-	0x7f, 0x41, 0xa9, 0x5d, 0x1e, 0x00, 0xf7, 0x59, 0x27, 0xf6, 0x4d, 0xe1, 0xb1, 0x56, 0xc8, 0x71,
-	0x6d, 0x37, 0xd1, 0x4e, 0xe0, 0xf4, 0x11, 0xb2, 0x18, 0xef, 0xb5, 0x0e, 0x7a, 0x4b, 0x18, 0xab,
-	0xb4, 0xf0, 0x78, 0xd8, 0x50, 0xca, 0xb2, 0x20, 0x76, 0x14, 0xb0, 0xf4, 0x29, 0xb2, 0x5c, 0x13,
-	0xb2, 0xcd, 0x43, 0xe1, 0x73, 0xab, 0xf4, 0x45, 0x61, 0x03, 0x5f, 0xf3, 0x3d, 0xbe, 0x23, 0x42,
-	0x61, 0x87, 0xf8, 0x5f, 0x22, 0x8e, 0x70, 0x4d, 0x5d, 0x92, 0x9f, 0x38, 0x57, 0xa6, 0xa5, 0xb6,
-	0x25, 0xf7, 0xac, 0x78, 0x4e, 0xd8, 0x61, 0x1d, 0x24, 0x0f, 0xed, 0x90, 0x5d, 0x4e, 0xf3, 0x53,
-	0xa8, 0xa1, 0x8f, 0x93, 0x13, 0x48, 0xd6, 0x41, 0x57, 0x5b, 0x5b, 0x67, 0xda, 0xca, 0x0a, 0xd9,
-	0xad, 0x83, 0x16, 0xca, 0x67, 0xbb, 0x71, 0x80, 0x62, 0x01, 0x3d, 0x4b, 0x8e, 0x35, 0x03, 0xae,
-	0xfd, 0xb2, 0xea, 0xf5, 0x84, 0xb5, 0x00, 0x89, 0x31, 0x8c, 0x8d, 0xb9, 0x1c, 0x3d, 0x47, 0x8e,
-	0xd5, 0xf8, 0x20, 0x45, 0x5d, 0x5c, 0x97, 0x2b, 0x76, 0xa5, 0x84, 0xa6, 0x3c, 0x92, 0x9e, 0x21,
-	0xb7, 0xb5, 0xb8, 0xee, 0x82, 0x4d, 0xa9, 0xa6, 0x78, 0x1e, 0xd8, 0x27, 0xe8, 0xc9, 0xe3, 0xe8,
-	0x13, 0xe4, 0x44, 0x8d, 0x0f, 0xd2, 0x0c, 0xba, 0x50, 0xa9, 0x80, 0x7d, 0x8a, 0xc6, 0x62, 0x05,
-	0x5d, 0x25, 0xb4, 0x1c, 0x44, 0x5a, 0x5e, 0x10, 0x3d, 0x61, 0x9f, 0x89, 0x94, 0x15, 0x20, 0x2d,
-	0xfb, 0x0c, 0x7d, 0x39, 0x14, 0xbd, 0x8b, 0xdc, 0xd8, 0xe6, 0x51, 0x68, 0xe3, 0x8d, 0x7d, 0x8e,
-	0xba, 0x0c, 0xa1, 0x0f, 0x93, 0xe3, 0x35, 0x21, 0x5d, 0x12, 0x5d, 0x36, 0x33, 0x3f, 0xfb, 0x02,
-	0xa5, 0xf9, 0x2c, 0xbd, 0x8f, 0xdc, 0x5c, 0x13, 0x32, 0x69, 0xa0, 0x96, 0xe8, 0x01, 0xfb, 0x12,
-	0xf5, 0x13, 0x30, 0xdd, 0x24, 0x4b, 0x19, 0xb2, 0xe6, 0xaa, 0x0c, 0xe9, 0x9f, 0x95, 0x55, 0x24,
-	0x2d, 0xfb, 0x0a, 0x8d, 0xd7, 0x90, 0xb9, 0x40, 0x75, 0xad, 0xfa, 0x4a, 0xbb, 0x6e, 0xe2, 0x61,
-	0x33, 0xe4, 0x26, 0x10, 0xb2, 0x5b, 0x8b, 0x42, 0x2b, 0xfa, 0xa1, 0x00, 0xcd, 0xae, 0x26, 0x81,
-	0x66, 0xcb, 0x5c, 0x06, 0xb7, 0x86, 0xc6, 0x82, 0x76, 0x4b, 0xa5, 0x19, 0xfc, 0x3a, 0xc9, 0xe0,
-	0x34, 0x45, 0xcb, 0xe4, 0x64, 0x86, 0x56, 0xd4, 0x9e, 0xdc, 0xe3, 0xda, 0x3f, 0xb0, 0xee, 0x37,
-	0x68, 0x9d, 0x29, 0xa2, 0x4f, 0x92, 0x3b, 0x32, 0xfe, 0xd9, 0xfe, 0x44, 0x88, 0x6f, 0x31, 0xc4,
-	0x0c, 0x09, 0x7d, 0x88, 0xdc, 0xda, 0x0c, 0xa2, 0x4e, 0x27, 0x84, 0x86, 0x8a, 0xa4, 0x8f, 0xb9,
-	0xfb, 0x0e, 0x7d, 0xd3, 0x8c, 0x2b, 0xd0, 0x06, 0xd7, 0x1b, 0x91, 0x8d, 0x34, 0xe0, 0x28, 0x7b,
-	0xeb, 0x10, 0x16, 0x68, 0x1c, 0xa6, 0x77, 0x92, 0x1b, 0x2e, 0x81, 0x56, 0x5b, 0xdc, 0x04, 0xec,
-	0xed, 0x43, 0xf1, 0x60, 0x4c, 0x01, 0x7a, 0x37, 0x39, 0x92, 0xd4, 0xc4, 0x8d, 0x19, 0xf6, 0x0e,
-	0xf2, 0x07, 0x31, 0x7a, 0x0f, 0x59, 0x48, 0x3e, 0x71, 0x99, 0x77, 0x71, 0x99, 0x31, 0xd0, 0xf5,
-	0xc9, 0x3a, 0x37, 0xd0, 0x00, 0xf7, 0x53, 0x66, 0x74, 0x9e, 0xd8, 0x7b, 0xa8, 0xcd, 0xe3, 0xe8,
-	0x79, 0xc2, 0x2a, 0xd0, 0x57, 0x46, 0xd8, 0xb2, 0x92, 0x56, 0x73, 0xcf, 0xb6, 0x34, 0x40, 0x05,
-	0xfa, 0x36, 0x60, 0xef, 0xa3, 0xaf, 0x50, 0xe0, 0x9a, 0x2c, 0x9d, 0x0e, 0xd9, 0xa4, 0x6b, 0x83,
-	0x67, 0x95, 0x66, 0x2f, 0x5c, 0x3f, 0x31, 0x3f, 0x26, 0x15, 0xf4, 0x31, 0x72, 0x7b, 0x4a, 0x8e,
-	0x4e, 0x50, 0x62, 0x7e, 0x11, 0xcd, 0x45, 0xbc, 0x1b, 0x23, 0xe3, 0xa3, 0xd5, 0x60, 0x3b, 0xbd,
-	0x84, 0xbe, 0x5c, 0x92, 0x3e, 0x4a, 0x16, 0xd3, 0xd3, 0xde, 0x80, 0xae, 0x30, 0x56, 0x0f, 0xd1,
-	0xf6, 0x32, 0xda, 0x0a, 0x68, 0xfa, 0x20, 0x39, 0x9a, 0xe5, 0x6e, 0x83, 0xc7, 0x3b, 0xfc, 0x03,
-	0x2d, 0x53, 0x84, 0x9b, 0x04, 0x95, 0xa7, 0x37, 0x11, 0x62, 0x7f, 0xa2, 0x2a, 0x43, 0x5c, 0x5f,
-	0x38, 0x4b, 0xd5, 0x06, 0x67, 0x2b, 0x91, 0x1d, 0x26, 0xba, 0xbf, 0x50, 0x97, 0x43, 0xb9, 0x23,
-	0xed, 0xee, 0x9f, 0xe4, 0xff, 0x93, 0xc9, 0x9d, 0x36, 0xd4, 0xdf, 0x68, 0x9c, 0x21, 0x71, 0x8d,
-	0x75, 0x31, 0x10, 0xd6, 0xd8, 0x10, 0x76, 0x42, 0xb5, 0x07, 0x1a, 0x03, 0xa7, 0x21, 0xfe, 0xc1,
-	0x10, 0x33, 0x45, 0x2e, 0x77, 0x71, 0xc3, 0x9b, 0x29, 0xfb, 0xbf, 0x49, 0xee, 0xf2, 0x69, 0x77,
-	0x46, 0xa6, 0xee, 0x9d, 0xd4, 0xfb, 0x5f, 0x72, 0x46, 0x0a, 0x15, 0xae, 0xd0, 0x15, 0xd5, 0xe3,
-	0x42, 0xae, 0x03, 0xf7, 0x94, 0x1c, 0x2d, 0xc2, 0xbe, 0x9f, 0x8b, 0x7b, 0x24, 0x97, 0x9c, 0x34,
-	0xe1, 0xfb, 0x00, 0x34, 0xfb, 0x21, 0xc7, 0x34, 0x22, 0x5d, 0x87, 0x21, 0xde, 0xe0, 0xd2, 0xe7,
-	0x8a, 0xfd, 0x88, 0xe2, 0x31, 0x90, 0xde, 0x4b, 0x6e, 0xc2, 0xef, 0xa4, 0x27, 0xd8, 0x4f, 0xa8,
-	0x1a, 0x47, 0x5d, 0x23, 0x22, 0xd0, 0x56, 0x61, 0x24, 0x2d, 0xd7, 0xc3, 0xea, 0x40, 0x58, 0xf6,
-	0x33, 0x8a, 0xf3, 0xb8, 0x6c, 0xcf, 0x4d, 0x08, 0xc1, 0x73, 0x63, 0xb5, 0xae, 0x95, 0xea, 0xb0,
-	0x5f, 0xc6, 0xf6, 0x3c, 0x4e, 0xba, 0x0e, 0x42, 0x7c, 0xad, 0xdb, 0xd5, 0xd0, 0xe5, 0x16, 0xd6,
-	0xa4, 0x8f, 0xbe, 0x5f, 0xd1, 0x57, 0xc4, 0xbb, 0x2d, 0xd6, 0xf8, 0xa0, 0xda, 0xe9, 0x40, 0x7c,
-	0x0d, 0xac, 0xf3, 0x90, 0x4b, 0x0f, 0xd8, 0x07, 0x87, 0x71, 0x56, 0xe4, 0x70, 0x71, 0xbb, 0x4f,
-	0x60, 0xdb, 0xd2, 0xd3, 0xd0, 0x73, 0xa5, 0xfc, 0xf0, 0x70, 0xd2, 0xee, 0x45, 0x0a, 0x7a, 0x3f,
-	0xb9, 0xa5, 0x7a, 0x19, 0x77, 0x3f, 0x5a, 0xed, 0x23, 0x34, 0x4d, 0xe2, 0xc9, 0x2b, 0x61, 0x54,
-	0xcf, 0xd1, 0xc1, 0x36, 0xec, 0xd5, 0xf9, 0xf4, 0x95, 0x30, 0x45, 0x26, 0xa6, 0x51, 0x3d, 0x33,
-	0xd3, 0x6b, 0x99, 0x69, 0x8a, 0x74, 0x9b, 0x4a, 0xf1, 0xf8, 0x05, 0x69, 0xd8, 0xeb, 0xa8, 0x9f,
-	0xc4, 0xdd, 0x94, 0xae, 0xf1, 0x41, 0x52, 0x62, 0xc3, 0xde, 0x40, 0xd9, 0x41, 0xcc, 0xdd, 0x1e,
-	0xee, 0x4d, 0x71, 0xb0, 0xb0, 0x86, 0xbd, 0x89, 0xc2, 0x69, 0x66, 0x9d, 0x5d, 0xd9, 0x5f, 0x2a,
-	0x5d, 0xdd, 0x5f, 0x2a, 0xfd, 0xb6, 0xbf, 0x54, 0x7a, 0xe5, 0xf7, 0xa5, 0xeb, 0x2e, 0xcd, 0xaf,
-	0x9c, 0x77, 0x0f, 0xec, 0x9d, 0xf9, 0xf8, 0xb5, 0x7d, 0xee, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x11, 0x0a, 0xd0, 0xe0, 0x86, 0x0b, 0x00, 0x00,
+	// 1263 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x57, 0xd9, 0x93, 0x13, 0x45,
+	0x18, 0x37, 0x08, 0x94, 0x36, 0x88, 0xd8, 0x1c, 0x36, 0x08, 0x5b, 0x88, 0xa5, 0xa2, 0x96, 0x20,
+	0x50, 0x6a, 0x59, 0x78, 0xd4, 0xe6, 0x58, 0x76, 0x91, 0x54, 0xc5, 0x64, 0x59, 0xaa, 0x78, 0x6b,
+	0x66, 0xbe, 0x64, 0x9a, 0x9d, 0x74, 0xc7, 0xee, 0x1e, 0x36, 0xf1, 0x3f, 0xf0, 0xcd, 0xe3, 0xc5,
+	0x47, 0xaf, 0x47, 0xef, 0x67, 0xf5, 0x19, 0x6f, 0x3c, 0xcb, 0xbb, 0xac, 0xf5, 0x5f, 0xf0, 0x7e,
+	0xb2, 0xba, 0xbf, 0xc9, 0x64, 0x33, 0x99, 0x84, 0xb7, 0xdd, 0xdf, 0xd1, 0xdd, 0xf3, 0xf5, 0x77,
+	0x74, 0xc8, 0x1e, 0xa3, 0x83, 0x63, 0x81, 0xd2, 0x70, 0x2c, 0x50, 0xb2, 0x2d, 0x3a, 0x47, 0x7b,
+	0x5a, 0x59, 0x45, 0x37, 0x3b, 0xe8, 0xf0, 0xb3, 0xfb, 0xc9, 0xb6, 0x4a, 0xc4, 0x85, 0xac, 0x78,
+	0x8e, 0x1e, 0x25, 0xf4, 0x34, 0x48, 0x30, 0xc2, 0x2c, 0x28, 0xbd, 0xba, 0x02, 0xda, 0x08, 0x25,
+	0x59, 0xe9, 0x50, 0xe9, 0xc8, 0xf6, 0x66, 0x01, 0x43, 0x0f, 0x93, 0xed, 0xad, 0x58, 0x59, 0xb3,
+	0x24, 0x6b, 0x3d, 0x15, 0x44, 0x2c, 0x3c, 0x54, 0x3a, 0xb2, 0xb9, 0x39, 0x86, 0xd1, 0x32, 0x39,
+	0x50, 0x17, 0x72, 0xde, 0x5a, 0x30, 0x96, 0x5b, 0xa1, 0xe4, 0x92, 0x0c, 0xe2, 0xc4, 0xd9, 0xab,
+	0x10, 0xf3, 0x01, 0x03, 0xef, 0x99, 0xa9, 0xa1, 0xf7, 0x92, 0x9d, 0x75, 0xde, 0x6f, 0x01, 0x84,
+	0x67, 0x95, 0x5a, 0xe5, 0x11, 0xf0, 0x90, 0xb5, 0xbd, 0x6f, 0x02, 0xf7, 0x5a, 0x21, 0xc7, 0xb5,
+	0x9d, 0x54, 0x9b, 0xc3, 0xe9, 0x43, 0x64, 0xaf, 0x3f, 0x6b, 0x03, 0xf4, 0xa2, 0x30, 0x56, 0x69,
+	0x11, 0xf0, 0xb8, 0xa9, 0x94, 0x65, 0x91, 0x77, 0x4c, 0x61, 0xe9, 0x19, 0x72, 0xa8, 0x2e, 0xe4,
+	0x0a, 0x8f, 0x45, 0xc8, 0xad, 0xd2, 0xe7, 0x85, 0x8d, 0x42, 0xcd, 0xd7, 0xf8, 0x45, 0x11, 0x0b,
+	0x3b, 0xc0, 0xef, 0x12, 0x7e, 0x85, 0x6b, 0xea, 0xd2, 0xf8, 0xf8, 0x58, 0x99, 0x65, 0xb5, 0x24,
+	0x79, 0x60, 0xc5, 0x65, 0x61, 0x07, 0x0d, 0x90, 0x3c, 0xb6, 0x03, 0x76, 0x29, 0x8b, 0xcf, 0x54,
+	0x0d, 0x7d, 0x94, 0xec, 0x43, 0xb2, 0x01, 0xba, 0xb6, 0xbc, 0x78, 0x7c, 0x45, 0x59, 0x21, 0x3b,
+	0x0d, 0xd0, 0x42, 0x85, 0x6c, 0xd5, 0x2f, 0x30, 0x5d, 0x40, 0x4f, 0x90, 0xdd, 0xad, 0x88, 0xeb,
+	0xb0, 0xa2, 0xba, 0x5d, 0x61, 0x2d, 0x40, 0x6a, 0x8c, 0xbd, 0xb1, 0x90, 0xa3, 0x77, 0x91, 0x1d,
+	0x2d, 0x08, 0x94, 0x0c, 0xdd, 0x8a, 0x2e, 0x4a, 0xac, 0xeb, 0xd5, 0x39, 0x94, 0x3e, 0x4e, 0xf6,
+	0xb7, 0x78, 0x1b, 0xdc, 0xdf, 0x66, 0x59, 0x9d, 0xeb, 0x85, 0xdc, 0xc2, 0x99, 0xc4, 0x58, 0xd1,
+	0x16, 0x10, 0x32, 0xe9, 0x3d, 0x33, 0x14, 0xee, 0x36, 0x97, 0xa4, 0x05, 0x7d, 0x99, 0xc7, 0xd9,
+	0x4e, 0x0a, 0x6f, 0x33, 0x8f, 0xbb, 0x6c, 0x4c, 0x73, 0x14, 0x6f, 0xa0, 0x87, 0xd9, 0xb8, 0x11,
+	0xa3, 0x0f, 0x90, 0x5d, 0xa3, 0x13, 0xba, 0x48, 0x94, 0x63, 0x15, 0xac, 0xb2, 0xa7, 0xbd, 0xb4,
+	0x88, 0x72, 0x35, 0xe1, 0xfe, 0x59, 0x50, 0x71, 0xac, 0xd6, 0xaa, 0xc2, 0x58, 0x2e, 0x03, 0x60,
+	0xda, 0x1b, 0x0a, 0x18, 0x7a, 0x92, 0xec, 0xae, 0xf3, 0x7e, 0x16, 0xaf, 0xec, 0xd4, 0x57, 0x4a,
+	0x18, 0xce, 0x22, 0x92, 0x1e, 0x27, 0xbb, 0x96, 0xb9, 0xee, 0x80, 0xcd, 0xa8, 0x96, 0x78, 0x06,
+	0xd8, 0x47, 0xe8, 0x29, 0xe2, 0xe8, 0x63, 0x64, 0x5f, 0x9d, 0xf7, 0xb3, 0xdc, 0x72, 0x4b, 0x65,
+	0x02, 0xf6, 0x31, 0x1a, 0xa7, 0x2b, 0xe8, 0x31, 0x42, 0x2b, 0x51, 0xa2, 0xe5, 0x59, 0xd1, 0x15,
+	0xf6, 0xa9, 0x44, 0x59, 0x01, 0xd2, 0xb2, 0x4f, 0xd0, 0x57, 0x40, 0xd1, 0x83, 0xe4, 0xc6, 0x15,
+	0x9e, 0xc4, 0xd6, 0x1f, 0xec, 0x53, 0xd4, 0x8d, 0x10, 0xfa, 0x20, 0xd9, 0x53, 0x17, 0xd2, 0x45,
+	0xce, 0xe5, 0xd9, 0xc8, 0xcf, 0x3e, 0x43, 0x69, 0x31, 0x4b, 0xef, 0x26, 0x3b, 0xea, 0x42, 0xa6,
+	0x57, 0xb4, 0x2c, 0xba, 0xc0, 0x3e, 0x47, 0x7d, 0x0e, 0xa6, 0xa7, 0xc9, 0xdc, 0x08, 0x99, 0x77,
+	0xf9, 0x0f, 0xd9, 0x97, 0x55, 0x54, 0x22, 0x2d, 0xfb, 0x02, 0x8d, 0xd7, 0x90, 0xb9, 0x85, 0x1a,
+	0x5a, 0xf5, 0x94, 0x76, 0x7d, 0x86, 0xc7, 0xad, 0x98, 0x9b, 0x48, 0xc8, 0x4e, 0x3d, 0x89, 0xad,
+	0xe8, 0xc5, 0x02, 0x34, 0xbb, 0x9a, 0x2e, 0x34, 0x5b, 0xe6, 0x22, 0xb8, 0x38, 0x30, 0x16, 0xb4,
+	0xdb, 0x2a, 0x8b, 0xe0, 0x97, 0x69, 0x04, 0x27, 0x29, 0x5a, 0x21, 0x07, 0x46, 0x68, 0x55, 0xad,
+	0xc9, 0x35, 0xae, 0xc3, 0x0d, 0xfb, 0x7e, 0x85, 0xd6, 0x99, 0x22, 0xfa, 0x04, 0xd9, 0x3f, 0xe2,
+	0xcf, 0xf5, 0x72, 0x4b, 0x7c, 0x8d, 0x4b, 0xcc, 0x90, 0xd0, 0xfb, 0xc9, 0x2d, 0xad, 0x28, 0x69,
+	0xb7, 0x63, 0x68, 0xaa, 0x44, 0x86, 0x18, 0xbb, 0x6f, 0xd0, 0x37, 0xc9, 0xb8, 0xaf, 0xf4, 0x71,
+	0x30, 0xa0, 0x5b, 0x6e, 0x66, 0x94, 0x95, 0x32, 0x96, 0x7d, 0x9b, 0x7e, 0xe5, 0x24, 0x45, 0x6b,
+	0xe4, 0x20, 0xa6, 0xeb, 0x7c, 0xa7, 0xa3, 0xa1, 0x33, 0x99, 0x9b, 0xdf, 0xa1, 0x77, 0xb6, 0xca,
+	0x25, 0xc6, 0x02, 0xd7, 0x0b, 0x89, 0x4d, 0x34, 0xe0, 0x70, 0x79, 0x63, 0x13, 0x26, 0xc6, 0x38,
+	0x4c, 0x6f, 0x23, 0x37, 0x5c, 0x00, 0xad, 0x16, 0xb9, 0x89, 0xd8, 0x9b, 0x9b, 0xfc, 0xa8, 0xca,
+	0x00, 0x7a, 0x3b, 0xd9, 0x96, 0xe6, 0x82, 0x6b, 0xfc, 0xec, 0x2d, 0xe4, 0x37, 0x62, 0xf4, 0x8e,
+	0xac, 0x6b, 0xe0, 0x36, 0x6f, 0x6f, 0x1a, 0x6b, 0x1b, 0xb8, 0xc9, 0x71, 0xb2, 0xab, 0xcc, 0x0d,
+	0x34, 0xc1, 0x05, 0xd3, 0x0c, 0xf3, 0x98, 0xbd, 0x83, 0xda, 0x22, 0x8e, 0x9e, 0x22, 0xac, 0x0a,
+	0x3d, 0x65, 0x84, 0xad, 0x28, 0x69, 0x35, 0x0f, 0xec, 0xb2, 0x06, 0xa8, 0x42, 0xcf, 0x46, 0xec,
+	0x5d, 0xf4, 0x4d, 0x15, 0xf8, 0xfd, 0xce, 0xb6, 0xb2, 0x79, 0x11, 0x37, 0x34, 0xb4, 0x45, 0x9f,
+	0xbd, 0x87, 0xe7, 0x2f, 0xe2, 0x5c, 0x3f, 0xc8, 0x5a, 0xfc, 0x68, 0x5c, 0xad, 0x40, 0x60, 0x95,
+	0x66, 0xcf, 0x5d, 0x9f, 0x1b, 0x02, 0x79, 0x05, 0x7d, 0x84, 0xdc, 0x9a, 0x91, 0xc3, 0x64, 0x4f,
+	0xcd, 0xcf, 0xa3, 0x79, 0x1a, 0xef, 0x3a, 0xde, 0xf8, 0x7c, 0x34, 0x58, 0xf9, 0x2f, 0xa0, 0xaf,
+	0x90, 0xa4, 0x0f, 0x93, 0xbd, 0x59, 0x61, 0x36, 0xa1, 0x23, 0x8c, 0xd5, 0x03, 0xb4, 0xbd, 0x88,
+	0xb6, 0x29, 0x34, 0xbd, 0x8f, 0xec, 0x1c, 0x85, 0x7b, 0x81, 0xfb, 0x13, 0xfe, 0x81, 0x96, 0x09,
+	0xc2, 0x35, 0xad, 0xea, 0x93, 0xa7, 0x11, 0x62, 0x7f, 0xa2, 0x6a, 0x84, 0xb8, 0xe4, 0x76, 0x96,
+	0x9a, 0x8d, 0x4e, 0x54, 0x13, 0x3b, 0x48, 0x75, 0x7f, 0xa1, 0xae, 0x80, 0x72, 0xd5, 0xe7, 0x1e,
+	0x11, 0xe9, 0xf7, 0xa7, 0xe3, 0x37, 0xab, 0xfd, 0xbf, 0xd1, 0x38, 0x43, 0xe2, 0x7a, 0xc0, 0xf9,
+	0x48, 0x58, 0x63, 0x63, 0xb8, 0x18, 0xab, 0x35, 0xd0, 0xb8, 0x70, 0xb6, 0xc4, 0x3f, 0xb8, 0xc4,
+	0x4c, 0x91, 0x8b, 0xdd, 0xb0, 0xf0, 0x72, 0xf6, 0x7f, 0xd3, 0xd8, 0x15, 0xd3, 0x2e, 0x47, 0x26,
+	0x1e, 0x0f, 0x99, 0xf7, 0xbf, 0x34, 0x47, 0xa6, 0x2a, 0xdc, 0x45, 0x57, 0x55, 0x97, 0x0b, 0x59,
+	0x06, 0x1e, 0x28, 0x39, 0xdc, 0x84, 0x7d, 0xbf, 0xd9, 0xa7, 0x65, 0x21, 0x99, 0x37, 0xe1, 0x23,
+	0x0f, 0x34, 0xfb, 0xa1, 0xc0, 0x34, 0x24, 0x5d, 0x51, 0x22, 0xde, 0xe4, 0x32, 0xe4, 0x8a, 0xfd,
+	0x88, 0xe2, 0x31, 0x90, 0xde, 0x49, 0x6e, 0xc2, 0xff, 0xd3, 0x32, 0x62, 0x3f, 0xa1, 0x6a, 0x1c,
+	0x75, 0xb5, 0x84, 0xc0, 0x8a, 0x8a, 0x13, 0x69, 0xb9, 0x1e, 0xd4, 0xfa, 0xc2, 0xb2, 0x9f, 0x51,
+	0x5c, 0xc4, 0x8d, 0xce, 0xdc, 0x82, 0x18, 0x02, 0x37, 0x01, 0x1a, 0x5a, 0xa9, 0x36, 0xfb, 0x65,
+	0xec, 0xcc, 0xe3, 0xa4, 0xab, 0x20, 0xc4, 0x87, 0x2d, 0x0d, 0xe6, 0x65, 0x88, 0xbe, 0x5f, 0xd1,
+	0x37, 0x8d, 0x77, 0x47, 0xac, 0xf3, 0x7e, 0xad, 0xdd, 0x06, 0x3f, 0xb1, 0xca, 0x3c, 0xf6, 0x8f,
+	0x8c, 0xf7, 0xb7, 0x60, 0x7b, 0x29, 0xe0, 0x7c, 0xb9, 0xe7, 0xb0, 0x25, 0x19, 0x68, 0xe8, 0xba,
+	0xab, 0xfc, 0x60, 0x4b, 0x5a, 0xee, 0xd3, 0x14, 0xf4, 0x1e, 0x72, 0x73, 0xed, 0x12, 0x9e, 0x7e,
+	0xb8, 0xdb, 0x87, 0x68, 0xca, 0xe3, 0xe9, 0x83, 0x26, 0xeb, 0xf4, 0x69, 0x62, 0x1b, 0xf6, 0xf2,
+	0xd6, 0xec, 0x41, 0x33, 0x41, 0xa6, 0xa6, 0xe1, 0x7d, 0x8e, 0x4c, 0xaf, 0x8c, 0x4c, 0x13, 0xa4,
+	0x3b, 0x54, 0x86, 0xfb, 0x9f, 0x01, 0x86, 0xbd, 0x8a, 0xfa, 0x3c, 0xee, 0x1a, 0x7b, 0x9d, 0xf7,
+	0xd3, 0x2b, 0x36, 0xec, 0x35, 0x94, 0x6d, 0xc4, 0xdc, 0xa0, 0x73, 0xcf, 0x9f, 0x8d, 0x17, 0x6b,
+	0xd8, 0xeb, 0x28, 0x9c, 0x64, 0xca, 0xec, 0xca, 0xfa, 0x5c, 0xe9, 0xea, 0xfa, 0x5c, 0xe9, 0xb7,
+	0xf5, 0xb9, 0xd2, 0x4b, 0xbf, 0xcf, 0x5d, 0x77, 0x61, 0xeb, 0xd1, 0x53, 0x6e, 0xac, 0x5d, 0xdc,
+	0xea, 0x7f, 0x32, 0x9d, 0xfc, 0x7f, 0x00, 0x76, 0x7d, 0xe2, 0x7e, 0x4b, 0x0d, 0x00, 0x00,
 }
 
 func (m *ChainConfig) Marshal() (dAtA []byte, err error) {
@@ -810,6 +891,15 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x80
 	}
+	if len(m.BLSWithdrawalPrefix) > 0 {
+		i -= len(m.BLSWithdrawalPrefix)
+		copy(dAtA[i:], m.BLSWithdrawalPrefix)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.BLSWithdrawalPrefix)))
+		i--
+		dAtA[i] = 0x13
+		i--
+		dAtA[i] = 0x92
+	}
 	if m.DepositContractTreeDepth != 0 {
 		i = encodeVarintConfig(dAtA, i, uint64(m.DepositContractTreeDepth))
 		i--
@@ -856,6 +946,20 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xe0
 	}
+	if m.TargetAggregatorsPerCommittee != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.TargetAggregatorsPerCommittee))
+		i--
+		dAtA[i] = 0xd
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.ProposerScoreBoost != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.ProposerScoreBoost))
+		i--
+		dAtA[i] = 0xd
+		i--
+		dAtA[i] = 0xa8
+	}
 	if m.ShuffleRoundCount != 0 {
 		i = encodeVarintConfig(dAtA, i, uint64(m.ShuffleRoundCount))
 		i--
@@ -947,6 +1051,48 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.ETH1FollowDistance != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.ETH1FollowDistance))
+		i--
+		dAtA[i] = 0x7
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.SecondsPerETH1Block != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SecondsPerETH1Block))
+		i--
+		dAtA[i] = 0x7
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.GenesisDelay != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.GenesisDelay))
+		i--
+		dAtA[i] = 0x7
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.IntervalsPerSlot != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.IntervalsPerSlot))
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.SafeSlotsToUpdateJustified != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SafeSlotsToUpdateJustified))
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.SecondsPerSlot != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SecondsPerSlot))
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xe8
+	}
 	if m.ShardCommitteePeriod != 0 {
 		i = encodeVarintConfig(dAtA, i, uint64(m.ShardCommitteePeriod))
 		i--
@@ -1068,6 +1214,24 @@ func (m *ChainConfig) Size() (n int) {
 	if m.ShardCommitteePeriod != 0 {
 		n += 2 + sovConfig(uint64(m.ShardCommitteePeriod))
 	}
+	if m.SecondsPerSlot != 0 {
+		n += 2 + sovConfig(uint64(m.SecondsPerSlot))
+	}
+	if m.SafeSlotsToUpdateJustified != 0 {
+		n += 2 + sovConfig(uint64(m.SafeSlotsToUpdateJustified))
+	}
+	if m.IntervalsPerSlot != 0 {
+		n += 2 + sovConfig(uint64(m.IntervalsPerSlot))
+	}
+	if m.GenesisDelay != 0 {
+		n += 2 + sovConfig(uint64(m.GenesisDelay))
+	}
+	if m.SecondsPerETH1Block != 0 {
+		n += 2 + sovConfig(uint64(m.SecondsPerETH1Block))
+	}
+	if m.ETH1FollowDistance != 0 {
+		n += 2 + sovConfig(uint64(m.ETH1FollowDistance))
+	}
 	if m.MaxCommitteesPerSlot != 0 {
 		n += 2 + sovConfig(uint64(m.MaxCommitteesPerSlot))
 	}
@@ -1107,6 +1271,12 @@ func (m *ChainConfig) Size() (n int) {
 	if m.ShuffleRoundCount != 0 {
 		n += 2 + sovConfig(uint64(m.ShuffleRoundCount))
 	}
+	if m.ProposerScoreBoost != 0 {
+		n += 2 + sovConfig(uint64(m.ProposerScoreBoost))
+	}
+	if m.TargetAggregatorsPerCommittee != 0 {
+		n += 2 + sovConfig(uint64(m.TargetAggregatorsPerCommittee))
+	}
 	if m.FarFutureEpoch != 0 {
 		n += 2 + sovConfig(uint64(m.FarFutureEpoch))
 	}
@@ -1127,6 +1297,10 @@ func (m *ChainConfig) Size() (n int) {
 	if m.DepositContractTreeDepth != 0 {
 		n += 2 + sovConfig(uint64(m.DepositContractTreeDepth))
 	}
+	l = len(m.BLSWithdrawalPrefix)
+	if l > 0 {
+		n += 2 + l + sovConfig(uint64(l))
+	}
 	if m.EpochsPerHistoricalVector != 0 {
 		n += 2 + sovConfig(uint64(m.EpochsPerHistoricalVector))
 	}
@@ -1458,6 +1632,120 @@ func (m *ChainConfig) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 109:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecondsPerSlot", wireType)
+			}
+			m.SecondsPerSlot = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SecondsPerSlot |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 110:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafeSlotsToUpdateJustified", wireType)
+			}
+			m.SafeSlotsToUpdateJustified = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SafeSlotsToUpdateJustified |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 111:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalsPerSlot", wireType)
+			}
+			m.IntervalsPerSlot = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IntervalsPerSlot |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 112:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisDelay", wireType)
+			}
+			m.GenesisDelay = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GenesisDelay |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 113:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecondsPerETH1Block", wireType)
+			}
+			m.SecondsPerETH1Block = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SecondsPerETH1Block |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 114:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ETH1FollowDistance", wireType)
+			}
+			m.ETH1FollowDistance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ETH1FollowDistance |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 200:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommitteesPerSlot", wireType)
@@ -1705,6 +1993,44 @@ func (m *ChainConfig) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 213:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerScoreBoost", wireType)
+			}
+			m.ProposerScoreBoost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposerScoreBoost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 214:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetAggregatorsPerCommittee", wireType)
+			}
+			m.TargetAggregatorsPerCommittee = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetAggregatorsPerCommittee |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 300:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field FarFutureEpoch", wireType)
@@ -1849,6 +2175,40 @@ func (m *ChainConfig) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 306:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BLSWithdrawalPrefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BLSWithdrawalPrefix = append(m.BLSWithdrawalPrefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.BLSWithdrawalPrefix == nil {
+				m.BLSWithdrawalPrefix = []byte{}
+			}
+			iNdEx = postIndex
 		case 400:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field EpochsPerHistoricalVector", wireType)
@@ -20,4 +20,16 @@ func BlockHeaderEqual(head1 *BlockHeader, head2 *BlockHeader) bool {
 		bytes.Equal(head1.StateRoot, head2.StateRoot) &&
 		bytes.Equal(head1.ParentRoot, head2.ParentRoot) &&
 		bytes.Equal(head1.BodyRoot, head2.BodyRoot)
+}
+
+func ETH1DataEqual(a *ETH1Data, b *ETH1Data) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.DepositCount == b.DepositCount &&
+		bytes.Equal(a.BlockHash, b.BlockHash) &&
+		bytes.Equal(a.DepositRoot, b.DepositRoot)
 }
\ No newline at end of file
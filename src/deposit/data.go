@@ -0,0 +1,141 @@
+package deposit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"io/ioutil"
+)
+
+// the deposit contract rejects deposits below 1 ETH
+const minDepositAmount = 1e9
+
+// DepositCLIVersion is the deposit CLI version the deposit data files are compatible with
+const DepositCLIVersion = "1.2.0"
+
+// NewDepositData returns the deposit data of amount gwei for pubKey, signed by keySigner with the fork agnostic
+// DOMAIN_DEPOSIT domain so it is valid on any fork of the chain.
+func NewDepositData(keySigner signer.Signer, pubKey []byte, withdrawalCredentials []byte, amount uint64) (*core.Deposit_DepositData, error) {
+	if len(pubKey) != 48 {
+		return nil, fmt.Errorf("deposit data: pubkey must be 48 bytes")
+	}
+	if len(withdrawalCredentials) != 32 {
+		return nil, fmt.Errorf("deposit data: withdrawal credentials must be 32 bytes")
+	}
+	if amount < minDepositAmount || amount > params.ChainConfig.MaxEffectiveBalance {
+		return nil, fmt.Errorf("deposit data: amount %d not in [%d, %d]", amount, uint64(minDepositAmount), params.ChainConfig.MaxEffectiveBalance)
+	}
+
+	sig, err := keySigner.SignDeposit(&core.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deposit data: %s", err.Error())
+	}
+	return &core.Deposit_DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+		Signature:             sig,
+	}, nil
+}
+
+// DataFileEntry is a deposit in the deposit CLI's deposit_data.json, byte fields are hex without 0x prefix
+type DataFileEntry struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name,omitempty"`
+	DepositCLIVersion     string `json:"deposit_cli_version"`
+}
+
+// NewDataFileEntry returns the deposit_data.json entry of data, networkName (e.g. mainnet) is optional
+func NewDataFileEntry(data *core.Deposit_DepositData, networkName string) (*DataFileEntry, error) {
+	msgRoot, err := (&core.DepositMessage{
+		PublicKey:             data.PublicKey,
+		WithdrawalCredentials: data.WithdrawalCredentials,
+		Amount:                data.Amount,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("deposit data file: %s", err.Error())
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("deposit data file: %s", err.Error())
+	}
+	return &DataFileEntry{
+		PubKey:                hex.EncodeToString(data.PublicKey),
+		WithdrawalCredentials: hex.EncodeToString(data.WithdrawalCredentials),
+		Amount:                data.Amount,
+		Signature:             hex.EncodeToString(data.Signature),
+		DepositMessageRoot:    hex.EncodeToString(msgRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(dataRoot[:]),
+		ForkVersion:           hex.EncodeToString(params.ChainConfig.GenesisForkVersion),
+		NetworkName:           networkName,
+		DepositCLIVersion:     DepositCLIVersion,
+	}, nil
+}
+
+// WriteDataFile writes the deposit_data.json file of data
+func WriteDataFile(file string, data []*core.Deposit_DepositData, networkName string) error {
+	entries := make([]*DataFileEntry, len(data))
+	for i, d := range data {
+		entry, err := NewDataFileEntry(d, networkName)
+		if err != nil {
+			return err
+		}
+		entries[i] = entry
+	}
+	byts, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("deposit data file: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(file, byts, 0644); err != nil {
+		return fmt.Errorf("deposit data file: %s", err.Error())
+	}
+	return nil
+}
+
+// LoadDataFile reads a deposit_data.json file, every entry's roots are verified
+func LoadDataFile(file string) ([]*core.Deposit_DepositData, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("deposit data file: %s", err.Error())
+	}
+	var entries []*DataFileEntry
+	if err := json.Unmarshal(byts, &entries); err != nil {
+		return nil, fmt.Errorf("deposit data file: %s", err.Error())
+	}
+
+	ret := make([]*core.Deposit_DepositData, len(entries))
+	for i, entry := range entries {
+		data := &core.Deposit_DepositData{Amount: entry.Amount}
+		if data.PublicKey, err = hex.DecodeString(entry.PubKey); err != nil {
+			return nil, fmt.Errorf("deposit data file: entry %d pubkey: %s", i, err.Error())
+		}
+		if data.WithdrawalCredentials, err = hex.DecodeString(entry.WithdrawalCredentials); err != nil {
+			return nil, fmt.Errorf("deposit data file: entry %d withdrawal credentials: %s", i, err.Error())
+		}
+		if data.Signature, err = hex.DecodeString(entry.Signature); err != nil {
+			return nil, fmt.Errorf("deposit data file: entry %d signature: %s", i, err.Error())
+		}
+		expected, err := NewDataFileEntry(data, entry.NetworkName)
+		if err != nil {
+			return nil, err
+		}
+		if expected.DepositMessageRoot != entry.DepositMessageRoot || expected.DepositDataRoot != entry.DepositDataRoot {
+			return nil, fmt.Errorf("deposit data file: entry %d roots do not match its data", i)
+		}
+		ret[i] = data
+	}
+	return ret, nil
+}
@@ -0,0 +1,70 @@
+package deposit
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestDepositData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deposit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	localSigner, err := signer.NewLocalSigner()
+	require.NoError(t, err)
+	data := make([]*core.Deposit_DepositData, 3)
+	tree := NewTree()
+	for i := range data {
+		pubKey, err := localSigner.AddKey([]byte(fmt.Sprintf("%d", i+1)))
+		require.NoError(t, err)
+		data[i], err = NewDepositData(localSigner, pubKey, make([]byte, 32), params.ChainConfig.MaxEffectiveBalance)
+		require.NoError(t, err)
+		require.NoError(t, tree.Add(data[i]))
+	}
+
+	// the deposits are processed
+	eth1Data, err := tree.Eth1Data(3, make([]byte, 32))
+	require.NoError(t, err)
+	state := &core.State{Eth1Data: eth1Data, Validators: []*core.Validator{}, Balances: []uint64{}}
+	deposits, err := tree.Deposits(0, 3, 3)
+	require.NoError(t, err)
+	require.NoError(t, state_transition.ProcessDeposits(state, deposits))
+	require.Len(t, state.Validators, 3)
+
+	// deposit_data.json round trip
+	file := path.Join(dir, "deposit_data.json")
+	require.NoError(t, WriteDataFile(file, data, "mainnet"))
+	loaded, err := LoadDataFile(file)
+	require.NoError(t, err)
+	require.EqualValues(t, data, loaded)
+
+	byts, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	var entries []*DataFileEntry
+	require.NoError(t, json.Unmarshal(byts, &entries))
+	require.Len(t, entries, 3)
+	require.EqualValues(t, "00000000", entries[0].ForkVersion)
+	require.EqualValues(t, params.ChainConfig.MaxEffectiveBalance, entries[0].Amount)
+	require.Len(t, entries[0].DepositDataRoot, 64)
+
+	entries[1].Amount--
+	byts, err = json.Marshal(entries)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(file, byts, 0644))
+	_, err = LoadDataFile(file)
+	require.EqualError(t, err, "deposit data file: entry 1 roots do not match its data")
+
+	_, err = NewDepositData(localSigner, data[0].PublicKey, make([]byte, 32), 1e9-1)
+	require.EqualError(t, err, "deposit data: amount 999999999 not in [1000000000, 32000000000]")
+	_, err = NewDepositData(localSigner, make([]byte, 48), make([]byte, 32), 1e9)
+	require.Error(t, err)
+}
@@ -0,0 +1,289 @@
+package deposit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"sync"
+)
+
+// Tree mirrors the deposit contract's incremental merkle tree of depth DEPOSIT_CONTRACT_TREE_DEPTH.
+// Roots (with the length mixed in) and proofs can be computed for any prefix of the deposits that are
+// still kept, deposits before a snapshot are represented only by the snapshot's frontier.
+type Tree struct {
+	lock sync.RWMutex
+	// number of deposits the tree was started from (with Snapshot) or pruned up to
+	snapshotCount uint64
+	// frontier[h] is the root of the last complete subtree of height h before snapshotCount,
+	// set only if bit h of snapshotCount is set
+	frontier [][32]byte
+	// deposits from snapshotCount on
+	leaves []*core.Deposit_DepositData
+	hashes [][32]byte
+
+	// roots of complete subtrees (all of their deposits were added), they don't change as deposits are added
+	nodesLock sync.Mutex
+	nodes     map[nodeKey][32]byte
+}
+
+type nodeKey struct {
+	h     uint64
+	index uint64
+}
+
+// Snapshot is the state of the deposit contract after Count deposits, enough to continue the tree from.
+type Snapshot struct {
+	Count    uint64
+	Frontier [][32]byte
+}
+
+func NewTree() *Tree {
+	return &Tree{frontier: make([][32]byte, depth()), nodes: make(map[nodeKey][32]byte)}
+}
+
+// NewTreeFromSnapshot continues a tree from a snapshot, deposits are added from snapshot.Count on
+func NewTreeFromSnapshot(snapshot *Snapshot) (*Tree, error) {
+	if uint64(len(snapshot.Frontier)) != depth() {
+		return nil, fmt.Errorf("deposit tree: snapshot frontier must have %d nodes", depth())
+	}
+	if snapshot.Count >= 1<<depth() {
+		return nil, fmt.Errorf("deposit tree: snapshot count too big")
+	}
+	frontier := make([][32]byte, depth())
+	copy(frontier, snapshot.Frontier)
+	return &Tree{snapshotCount: snapshot.Count, frontier: frontier, nodes: make(map[nodeKey][32]byte)}, nil
+}
+
+// Add appends the next deposit
+func (t *Tree) Add(data *core.Deposit_DepositData) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.snapshotCount+uint64(len(t.leaves)) >= 1<<depth() {
+		return fmt.Errorf("deposit tree: tree is full")
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("deposit tree: %s", err.Error())
+	}
+	t.leaves = append(t.leaves, data)
+	t.hashes = append(t.hashes, root)
+	return nil
+}
+
+// DepositCount returns the number of deposits added to the tree (including the ones before its snapshot)
+func (t *Tree) DepositCount() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.count()
+}
+
+// DepositRoot returns the deposit contract's root after count deposits,
+// hash_tree_root(List[DepositData, 2**DEPOSIT_CONTRACT_TREE_DEPTH]) of the first count deposits.
+func (t *Tree) DepositRoot(count uint64) ([32]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.validateCount(count); err != nil {
+		return [32]byte{}, err
+	}
+	root, err := t.node(depth(), 0, count)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return mixInLength(root, count), nil
+}
+
+// Eth1Data returns the deposit root and count after count deposits with blockHash
+func (t *Tree) Eth1Data(count uint64, blockHash []byte) (*core.ETH1Data, error) {
+	root, err := t.DepositRoot(count)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ETH1Data{DepositRoot: root[:], DepositCount: count, BlockHash: blockHash}, nil
+}
+
+// Proof returns the DEPOSIT_CONTRACT_TREE_DEPTH + 1 proof (the last element being the length mix in) of the
+// deposit at index against DepositRoot(count).
+func (t *Tree) Proof(index uint64, count uint64) ([][]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.proof(index, count)
+}
+
+// Deposit returns the deposit at index with its proof against DepositRoot(count)
+func (t *Tree) Deposit(index uint64, count uint64) (*core.Deposit, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	proof, err := t.proof(index, count)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Deposit{Proof: proof, Data: t.leaves[index-t.snapshotCount]}, nil
+}
+
+func (t *Tree) proof(index uint64, count uint64) ([][]byte, error) {
+	if err := t.validateCount(count); err != nil {
+		return nil, err
+	}
+	if index < t.snapshotCount || index >= count {
+		return nil, fmt.Errorf("deposit tree: deposit %d not in [%d, %d)", index, t.snapshotCount, count)
+	}
+
+	ret := make([][]byte, 0, depth()+1)
+	for h := uint64(0); h < depth(); h++ {
+		sibling, err := t.node(h, (index>>h)^1, count)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, sibling[:])
+	}
+	length := make([]byte, 32)
+	binary.LittleEndian.PutUint64(length, count)
+	return append(ret, length), nil
+}
+
+// Deposits returns the deposits in [from, to) with proofs against DepositRoot(count), the deposits a block
+// includes when the state's eth1_deposit_index is from and its eth1_data.deposit_count is count.
+func (t *Tree) Deposits(from uint64, to uint64, count uint64) ([]*core.Deposit, error) {
+	ret := make([]*core.Deposit, 0)
+	for index := from; index < to; index++ {
+		deposit, err := t.Deposit(index, count)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, deposit)
+	}
+	return ret, nil
+}
+
+// Snapshot returns the snapshot of the tree after count deposits
+func (t *Tree) Snapshot(count uint64) (*Snapshot, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.snapshot(count)
+}
+
+// Prune drops the deposits before count, keeping only their frontier. Roots and proofs can be computed
+// from count on.
+func (t *Tree) Prune(count uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	snapshot, err := t.snapshot(count)
+	if err != nil {
+		return err
+	}
+	drop := count - t.snapshotCount
+	t.leaves = t.leaves[drop:]
+	t.hashes = t.hashes[drop:]
+	t.snapshotCount = count
+	t.frontier = snapshot.Frontier
+
+	t.nodesLock.Lock()
+	defer t.nodesLock.Unlock()
+	for key := range t.nodes {
+		if (key.index+1)<<key.h <= count {
+			delete(t.nodes, key)
+		}
+	}
+	return nil
+}
+
+func (t *Tree) snapshot(count uint64) (*Snapshot, error) {
+	if err := t.validateCount(count); err != nil {
+		return nil, err
+	}
+	frontier := make([][32]byte, depth())
+	for h := uint64(0); h < depth(); h++ {
+		if (count>>h)&1 == 0 {
+			continue
+		}
+		node, err := t.node(h, (count>>h)-1, count)
+		if err != nil {
+			return nil, err
+		}
+		frontier[h] = node
+	}
+	return &Snapshot{Count: count, Frontier: frontier}, nil
+}
+
+func (t *Tree) count() uint64 {
+	return t.snapshotCount + uint64(len(t.leaves))
+}
+
+func (t *Tree) validateCount(count uint64) error {
+	if count < t.snapshotCount || count > t.count() {
+		return fmt.Errorf("deposit tree: count %d not in [%d, %d]", count, t.snapshotCount, t.count())
+	}
+	return nil
+}
+
+// the root of the subtree of height h at index, counting only the first count deposits
+func (t *Tree) node(h uint64, index uint64, count uint64) ([32]byte, error) {
+	start := index << h
+	if start >= count {
+		return zeroHashes[h], nil
+	}
+	end := start + 1<<h
+	// before the snapshot, only the frontier is kept
+	if end <= t.snapshotCount {
+		if (t.snapshotCount>>h)&1 == 1 && (t.snapshotCount>>h)-1 == index {
+			return t.frontier[h], nil
+		}
+		return [32]byte{}, fmt.Errorf("deposit tree: node %d at height %d was pruned", index, h)
+	}
+	if h == 0 {
+		return t.hashes[index-t.snapshotCount], nil
+	}
+	complete := end <= count
+	if complete {
+		t.nodesLock.Lock()
+		root, found := t.nodes[nodeKey{h: h, index: index}]
+		t.nodesLock.Unlock()
+		if found {
+			return root, nil
+		}
+	}
+
+	left, err := t.node(h-1, index*2, count)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	right, err := t.node(h-1, index*2+1, count)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	root := hashutil.Hash(append(left[:], right[:]...))
+	if complete {
+		t.nodesLock.Lock()
+		t.nodes[nodeKey{h: h, index: index}] = root
+		t.nodesLock.Unlock()
+	}
+	return root, nil
+}
+
+func mixInLength(root [32]byte, length uint64) [32]byte {
+	lengthByts := make([]byte, 32)
+	binary.LittleEndian.PutUint64(lengthByts, length)
+	return hashutil.Hash(append(root[:], lengthByts...))
+}
+
+func depth() uint64 {
+	return params.ChainConfig.DepositContractTreeDepth
+}
+
+// zeroHashes[h] is the root of an empty subtree of height h
+var zeroHashes = func() [][32]byte {
+	ret := make([][32]byte, 64)
+	for h := 1; h < len(ret); h++ {
+		ret[h] = hashutil.Hash(append(ret[h-1][:], ret[h-1][:]...))
+	}
+	return ret
+}()
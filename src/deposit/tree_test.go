@@ -0,0 +1,201 @@
+package deposit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// signed deposit data of count new validators
+func depositData(t *testing.T, count int) []*core.Deposit_DepositData {
+	domain, err := shared.ComputeDomain(params.ChainConfig.DomainDeposit, nil, nil)
+	require.NoError(t, err)
+
+	ret := make([]*core.Deposit_DepositData, count)
+	for i := range ret {
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.SetHexString(fmt.Sprintf("%x", i+1)))
+		msg := &core.DepositMessage{
+			PublicKey:             sk.GetPublicKey().Serialize(),
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                params.ChainConfig.MaxEffectiveBalance,
+		}
+		root, err := shared.ComputeSigningRoot(msg, domain)
+		require.NoError(t, err)
+		ret[i] = &core.Deposit_DepositData{
+			PublicKey:             msg.PublicKey,
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                msg.Amount,
+			Signature:             sk.SignByte(root[:]).Serialize(),
+		}
+	}
+	return ret
+}
+
+func expectedRoot(t *testing.T, data []*core.Deposit_DepositData) [32]byte {
+	leaves := make([][]byte, len(data))
+	for i, d := range data {
+		root, err := d.HashTreeRoot()
+		require.NoError(t, err)
+		leaves[i] = root[:]
+	}
+	// the deposit contract's root before any deposit
+	if len(leaves) == 0 {
+		root, err := hex.DecodeString("d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")
+		require.NoError(t, err)
+		ret := [32]byte{}
+		copy(ret[:], root)
+		return ret
+	}
+	trie, err := trieutil.GenerateTrieFromItems(leaves, int(params.ChainConfig.DepositContractTreeDepth))
+	require.NoError(t, err)
+	return trie.Root()
+}
+
+func TestDepositRootAndProofs(t *testing.T) {
+	data := depositData(t, 13)
+	tree := NewTree()
+	for _, d := range data {
+		require.NoError(t, tree.Add(d))
+	}
+	require.EqualValues(t, 13, tree.DepositCount())
+
+	for count := 0; count <= len(data); count++ {
+		root, err := tree.DepositRoot(uint64(count))
+		require.NoError(t, err)
+		require.EqualValues(t, expectedRoot(t, data[:count]), root)
+
+		for index := 0; index < count; index++ {
+			deposit, err := tree.Deposit(uint64(index), uint64(count))
+			require.NoError(t, err)
+			require.Len(t, deposit.Proof, int(params.ChainConfig.DepositContractTreeDepth)+1)
+			require.EqualValues(t, data[index], deposit.Data)
+			require.NoError(t, state_transition.VerifyDepositMerkleBranch(root[:], uint64(index), deposit))
+		}
+	}
+
+	_, err := tree.DepositRoot(14)
+	require.EqualError(t, err, "deposit tree: count 14 not in [0, 13]")
+	_, err = tree.Proof(5, 5)
+	require.EqualError(t, err, "deposit tree: deposit 5 not in [0, 5)")
+}
+
+func TestProcessDeposits(t *testing.T) {
+	data := depositData(t, 10)
+	tree := NewTree()
+	for _, d := range data {
+		require.NoError(t, tree.Add(d))
+	}
+
+	eth1Data, err := tree.Eth1Data(10, make([]byte, 32))
+	require.NoError(t, err)
+	state := &core.State{
+		Eth1Data:         eth1Data,
+		Eth1DepositIndex: 4,
+		Validators:       []*core.Validator{},
+		Balances:         []uint64{},
+	}
+	deposits, err := tree.Deposits(4, 10, 10)
+	require.NoError(t, err)
+	require.NoError(t, state_transition.ProcessDeposits(state, deposits))
+	require.EqualValues(t, 10, state.Eth1DepositIndex)
+	require.Len(t, state.Validators, 6)
+}
+
+func TestSnapshot(t *testing.T) {
+	data := depositData(t, 21)
+	full := NewTree()
+	for _, d := range data {
+		require.NoError(t, full.Add(d))
+	}
+
+	for snapshotCount := 0; snapshotCount <= len(data); snapshotCount++ {
+		snapshot, err := full.Snapshot(uint64(snapshotCount))
+		require.NoError(t, err)
+
+		tree, err := NewTreeFromSnapshot(snapshot)
+		require.NoError(t, err)
+		for _, d := range data[snapshotCount:] {
+			require.NoError(t, tree.Add(d))
+		}
+		require.EqualValues(t, len(data), tree.DepositCount())
+
+		for count := snapshotCount; count <= len(data); count++ {
+			root, err := tree.DepositRoot(uint64(count))
+			require.NoError(t, err)
+			expected, err := full.DepositRoot(uint64(count))
+			require.NoError(t, err)
+			require.EqualValues(t, expected, root)
+
+			for index := snapshotCount; index < count; index++ {
+				proof, err := tree.Proof(uint64(index), uint64(count))
+				require.NoError(t, err)
+				expectedProof, err := full.Proof(uint64(index), uint64(count))
+				require.NoError(t, err)
+				require.EqualValues(t, expectedProof, proof)
+			}
+		}
+
+		if snapshotCount > 0 {
+			_, err = tree.Proof(uint64(snapshotCount-1), uint64(len(data)))
+			require.Error(t, err)
+			_, err = tree.DepositRoot(uint64(snapshotCount - 1))
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	data := depositData(t, 9)
+	tree := NewTree()
+	for _, d := range data[:6] {
+		require.NoError(t, tree.Add(d))
+	}
+	// cached subtree roots are kept across pruning
+	before, err := tree.DepositRoot(6)
+	require.NoError(t, err)
+	require.NoError(t, tree.Prune(5))
+	require.EqualValues(t, 6, tree.DepositCount())
+	after, err := tree.DepositRoot(6)
+	require.NoError(t, err)
+	require.EqualValues(t, before, after)
+	for _, d := range data[6:] {
+		require.NoError(t, tree.Add(d))
+	}
+
+	root, err := tree.DepositRoot(9)
+	require.NoError(t, err)
+	require.EqualValues(t, expectedRoot(t, data), root)
+	for index := uint64(5); index < 9; index++ {
+		deposit, err := tree.Deposit(index, 9)
+		require.NoError(t, err)
+		require.NoError(t, state_transition.VerifyDepositMerkleBranch(root[:], index, deposit))
+	}
+	_, err = tree.Deposit(4, 9)
+	require.EqualError(t, err, "deposit tree: deposit 4 not in [5, 9)")
+	require.Error(t, tree.Prune(4))
+
+	// deposits read while pruning are either returned with their proof or not found
+	read := make(map[uint64]*core.Deposit)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for index := uint64(6); index < 9; index++ {
+			if deposit, err := tree.Deposit(index, 9); err == nil {
+				read[index] = deposit
+			}
+		}
+	}()
+	require.NoError(t, tree.Prune(8))
+	<-done
+	for index, deposit := range read {
+		require.EqualValues(t, data[index], deposit.Data)
+	}
+}
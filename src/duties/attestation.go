@@ -0,0 +1,117 @@
+package duties
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+/**
+Validator guide, attestation data:
+    # head_block_root is the root of the head block per fork choice, head_state the state of the head block
+    # advanced to slot
+    attestation_data.beacon_block_root = head_block_root
+    attestation_data.source = head_state.current_justified_checkpoint
+    epoch_boundary_block_root = head_block_root if start_slot == head_state.slot
+                                else get_block_root(head_state, get_current_epoch(head_state))
+    attestation_data.target = Checkpoint(epoch=get_current_epoch(head_state), root=epoch_boundary_block_root)
+ */
+// GetAttestationData returns the attestation data of committee index at slot. state is the head state, the post
+// state of the head block (at most at slot), headRoot the head block's root (e.g. forkchoice.Store.GetHead) or nil
+// for the state's latest block.
+func GetAttestationData(state *core.State, slot uint64, committeeIndex uint64, headRoot []byte) (*core.AttestationData, error) {
+	if slot < state.Slot {
+		return nil, fmt.Errorf("attestation data: slot %d before the head state's slot %d", slot, state.Slot)
+	}
+	if headRoot == nil {
+		root, err := latestBlockRoot(state)
+		if err != nil {
+			return nil, fmt.Errorf("attestation data: %s", err.Error())
+		}
+		headRoot = root[:]
+	}
+
+	headState := shared.CopyState(state)
+	if err := state_transition.NewStateTransition().ProcessSlots(headState, slot); err != nil {
+		return nil, fmt.Errorf("attestation data: %s", err.Error())
+	}
+	if committees := shared.GetCommitteeCountPerSlot(headState, slot); committeeIndex >= committees {
+		return nil, fmt.Errorf("attestation data: committee index %d not in [0, %d)", committeeIndex, committees)
+	}
+
+	epoch := shared.GetCurrentEpoch(headState)
+	targetRoot := headRoot
+	if shared.ComputeStartSlotAtEpoch(epoch) != headState.Slot {
+		root, err := shared.GetBlockRoot(headState, epoch)
+		if err != nil {
+			return nil, fmt.Errorf("attestation data: %s", err.Error())
+		}
+		targetRoot = root
+	}
+
+	return &core.AttestationData{
+		Slot:            slot,
+		CommitteeIndex:  committeeIndex,
+		BeaconBlockRoot: headRoot,
+		Source: &core.Checkpoint{
+			Epoch: headState.CurrentJustifiedCheckpoint.Epoch,
+			Root:  headState.CurrentJustifiedCheckpoint.Root,
+		},
+		Target: &core.Checkpoint{
+			Epoch: epoch,
+			Root:  targetRoot,
+		},
+	}, nil
+}
+
+// SignAttestation returns the attestation of data by the validator at committeePosition of data's committee, its
+// aggregation bits have only the validator's bit set. The validator's key signs with keySigner.
+func SignAttestation(state *core.State, data *core.AttestationData, committeePosition uint64, keySigner signer.Signer) (*core.Attestation, error) {
+	committee, err := shared.GetBeaconCommittee(state, data.Slot, data.CommitteeIndex)
+	if err != nil {
+		return nil, fmt.Errorf("sign attestation: %s", err.Error())
+	}
+	if committeePosition >= uint64(len(committee)) {
+		return nil, fmt.Errorf("sign attestation: committee position %d not in [0, %d)", committeePosition, len(committee))
+	}
+	validator := shared.GetValidator(state, committee[committeePosition])
+	if validator == nil {
+		return nil, fmt.Errorf("sign attestation: validator %d not found", committee[committeePosition])
+	}
+
+	sig, err := keySigner.SignAttestationData(state, data, validator.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign attestation: %s", err.Error())
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(committeePosition, true)
+	return &core.Attestation{
+		AggregationBits: bits,
+		Data:            data,
+		Signature:       sig,
+	}, nil
+}
+
+// the root of the state's latest block, its state root is filled in by the next process_slot if not yet
+func latestBlockRoot(state *core.State) ([32]byte, error) {
+	header := &core.BlockHeader{
+		Slot:          state.LatestBlockHeader.Slot,
+		ProposerIndex: state.LatestBlockHeader.ProposerIndex,
+		ParentRoot:    state.LatestBlockHeader.ParentRoot,
+		StateRoot:     state.LatestBlockHeader.StateRoot,
+		BodyRoot:      state.LatestBlockHeader.BodyRoot,
+	}
+	if bytes.Equal(header.StateRoot, params.ChainConfig.ZeroHash) {
+		stateRoot, err := state.HashTreeRoot()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
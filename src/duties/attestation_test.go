@@ -0,0 +1,83 @@
+package duties
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/genesis"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAttestationData(t *testing.T) {
+	state, keys, err := genesis.InteropGenesisState(0, params.ChainConfig.MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+	localSigner, err := signer.NewLocalSigner()
+	require.NoError(t, err)
+	for _, key := range keys {
+		_, err := localSigner.AddKey(key.Serialize())
+		require.NoError(t, err)
+	}
+	genesisRoot, err := latestBlockRoot(state)
+	require.NoError(t, err)
+
+	// a block at slot 1
+	st := state_transition.NewStateTransition()
+	stateCopy := shared.CopyState(state)
+	require.NoError(t, st.ProcessSlots(stateCopy, 1))
+	proposer, err := shared.GetBlockProposerIndex(stateCopy)
+	require.NoError(t, err)
+	randaoReveal, err := localSigner.SignRandaoReveal(stateCopy, 0, state.Validators[proposer].PublicKey)
+	require.NoError(t, err)
+	signedBlock, err := st.BuildBlock(state, 1, &core.BlockBody{
+		RandaoReveal: randaoReveal,
+		Eth1Data:     state.Eth1Data,
+		Graffiti:     make([]byte, 32),
+	}, localSigner)
+	require.NoError(t, err)
+	headState, err := st.ExecuteStateTransition(state, signedBlock, true)
+	require.NoError(t, err)
+	headRoot, err := signedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// the head is the state's latest block, the target the genesis block
+	data, err := GetAttestationData(headState, 2, 0, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, data.Slot)
+	require.EqualValues(t, headRoot[:], data.BeaconBlockRoot)
+	require.EqualValues(t, 0, data.Target.Epoch)
+	require.EqualValues(t, genesisRoot[:], data.Target.Root)
+	require.EqualValues(t, headState.CurrentJustifiedCheckpoint, data.Source)
+
+	// at an epoch's first slot the head is the target
+	boundary, err := GetAttestationData(headState, params.ChainConfig.SlotsInEpoch, 0, headRoot[:])
+	require.NoError(t, err)
+	require.EqualValues(t, 1, boundary.Target.Epoch)
+	require.EqualValues(t, headRoot[:], boundary.Target.Root)
+
+	// every committee member's attestation is valid
+	attestingState := shared.CopyState(headState)
+	require.NoError(t, st.ProcessSlots(attestingState, 3))
+	committee, err := shared.GetBeaconCommittee(attestingState, 2, 0)
+	require.NoError(t, err)
+	attestations := make([]*core.Attestation, 0)
+	for position, index := range committee {
+		attestation, err := SignAttestation(attestingState, data, uint64(position), localSigner)
+		require.NoError(t, err)
+		require.EqualValues(t, []int{position}, attestation.AggregationBits.BitIndices())
+		indexed, err := shared.GetIndexedAttestation(attestingState, attestation)
+		require.NoError(t, err)
+		require.EqualValues(t, []uint64{index}, indexed.AttestingIndices)
+		attestations = append(attestations, attestation)
+	}
+	require.NoError(t, state_transition.ProcessBlockAttestations(shared.CopyState(attestingState), attestations))
+
+	_, err = SignAttestation(attestingState, data, uint64(len(committee)), localSigner)
+	require.EqualError(t, err, "sign attestation: committee position 4 not in [0, 4)")
+	_, err = GetAttestationData(headState, 0, 0, nil)
+	require.EqualError(t, err, "attestation data: slot 0 before the head state's slot 1")
+	_, err = GetAttestationData(headState, 2, 1, nil)
+	require.EqualError(t, err, "attestation data: committee index 1 not in [0, 1)")
+}
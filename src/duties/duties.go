@@ -0,0 +1,135 @@
+package duties
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+)
+
+// ProposerDuty is a slot the validator proposes a block at
+type ProposerDuty struct {
+	Slot           uint64
+	ValidatorIndex uint64
+	PublicKey      []byte
+}
+
+// AttesterDuty is the committee the validator attests with during the epoch
+type AttesterDuty struct {
+	Slot           uint64
+	CommitteeIndex uint64
+	// the validator's position in the committee, the aggregation bit it sets
+	CommitteePosition uint64
+	CommitteeLength   uint64
+	CommitteesAtSlot  uint64
+	ValidatorIndex    uint64
+	PublicKey         []byte
+}
+
+// Duties are the proposer and attester duties of all validators for an epoch
+type Duties struct {
+	Epoch     uint64
+	Proposers []*ProposerDuty
+	Attesters []*AttesterDuty
+
+	proposersByIndex map[uint64][]*ProposerDuty
+	attestersByIndex map[uint64]*AttesterDuty
+	indexByPubKey    map[string]uint64
+}
+
+// GetDuties calculates the duties for epoch from state, epoch can be the state's current epoch or up to
+// MIN_SEED_LOOKAHEAD epochs after it, their seeds are already known.
+// Proposer duties of a future epoch assume effective balances don't change in the epoch transition, the proposer
+// selection depends on them.
+func GetDuties(state *core.State, epoch uint64) (*Duties, error) {
+	currentEpoch := shared.GetCurrentEpoch(state)
+	if epoch < currentEpoch || epoch > currentEpoch+params.ChainConfig.MinSeedLookahead {
+		return nil, fmt.Errorf("get duties: epoch %d not in [%d, %d]", epoch, currentEpoch, currentEpoch+params.ChainConfig.MinSeedLookahead)
+	}
+
+	ret := &Duties{
+		Epoch:            epoch,
+		Proposers:        make([]*ProposerDuty, 0),
+		Attesters:        make([]*AttesterDuty, 0),
+		proposersByIndex: make(map[uint64][]*ProposerDuty),
+		attestersByIndex: make(map[uint64]*AttesterDuty),
+		indexByPubKey:    make(map[string]uint64),
+	}
+
+	// get_beacon_proposer_index works on the state's slot
+	stateCopy := shared.CopyState(state)
+	startSlot := shared.ComputeStartSlotAtEpoch(epoch)
+	for slot := startSlot; slot < startSlot+params.ChainConfig.SlotsInEpoch; slot++ {
+		// there is no proposer for genesis
+		if slot == 0 {
+			continue
+		}
+		stateCopy.Slot = slot
+		index, err := shared.GetBlockProposerIndex(stateCopy)
+		if err != nil {
+			return nil, fmt.Errorf("get duties: %s", err.Error())
+		}
+		duty := &ProposerDuty{
+			Slot:           slot,
+			ValidatorIndex: index,
+			PublicKey:      state.Validators[index].PublicKey,
+		}
+		ret.Proposers = append(ret.Proposers, duty)
+		ret.proposersByIndex[index] = append(ret.proposersByIndex[index], duty)
+		ret.indexByPubKey[hex.EncodeToString(duty.PublicKey)] = index
+	}
+
+	for slot := startSlot; slot < startSlot+params.ChainConfig.SlotsInEpoch; slot++ {
+		committeesAtSlot := shared.GetCommitteeCountPerSlot(state, slot)
+		for committeeIndex := uint64(0); committeeIndex < committeesAtSlot; committeeIndex++ {
+			committee, err := shared.GetBeaconCommittee(state, slot, committeeIndex)
+			if err != nil {
+				return nil, fmt.Errorf("get duties: %s", err.Error())
+			}
+			for position, index := range committee {
+				duty := &AttesterDuty{
+					Slot:              slot,
+					CommitteeIndex:    committeeIndex,
+					CommitteePosition: uint64(position),
+					CommitteeLength:   uint64(len(committee)),
+					CommitteesAtSlot:  committeesAtSlot,
+					ValidatorIndex:    index,
+					PublicKey:         state.Validators[index].PublicKey,
+				}
+				ret.Attesters = append(ret.Attesters, duty)
+				ret.attestersByIndex[index] = duty
+				ret.indexByPubKey[hex.EncodeToString(duty.PublicKey)] = index
+			}
+		}
+	}
+	return ret, nil
+}
+
+// ProposerDuties returns the slots the validator proposes at, if any
+func (d *Duties) ProposerDuties(validatorIndex uint64) []*ProposerDuty {
+	return d.proposersByIndex[validatorIndex]
+}
+
+// AttesterDuty returns the validator's committee, nil if it's not active in the epoch
+func (d *Duties) AttesterDuty(validatorIndex uint64) *AttesterDuty {
+	return d.attestersByIndex[validatorIndex]
+}
+
+// ProposerDutiesByPubKey returns the slots the validator proposes at, if any
+func (d *Duties) ProposerDutiesByPubKey(pubKey []byte) []*ProposerDuty {
+	index, found := d.indexByPubKey[hex.EncodeToString(pubKey)]
+	if !found {
+		return nil
+	}
+	return d.ProposerDuties(index)
+}
+
+// AttesterDutyByPubKey returns the validator's committee, nil if it's not active in the epoch
+func (d *Duties) AttesterDutyByPubKey(pubKey []byte) *AttesterDuty {
+	index, found := d.indexByPubKey[hex.EncodeToString(pubKey)]
+	if !found {
+		return nil
+	}
+	return d.AttesterDuty(index)
+}
@@ -0,0 +1,51 @@
+package duties
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/genesis"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetDuties(t *testing.T) {
+	state, _, err := genesis.InteropGenesisState(0, params.ChainConfig.MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+
+	duties, err := GetDuties(state, 0)
+	require.NoError(t, err)
+	// no proposer at genesis
+	require.Len(t, duties.Proposers, int(params.ChainConfig.SlotsInEpoch)-1)
+	// every active validator attests once
+	require.Len(t, duties.Attesters, len(state.Validators))
+
+	for _, duty := range duties.Attesters {
+		committee, err := shared.GetBeaconCommittee(state, duty.Slot, duty.CommitteeIndex)
+		require.NoError(t, err)
+		require.EqualValues(t, duty.ValidatorIndex, committee[duty.CommitteePosition])
+		require.EqualValues(t, len(committee), duty.CommitteeLength)
+		require.EqualValues(t, duty, duties.AttesterDuty(duty.ValidatorIndex))
+		require.EqualValues(t, duty, duties.AttesterDutyByPubKey(state.Validators[duty.ValidatorIndex].PublicKey))
+	}
+	require.Nil(t, duties.AttesterDuty(uint64(len(state.Validators))))
+	require.Nil(t, duties.AttesterDutyByPubKey(make([]byte, 48)))
+
+	// next epoch proposers match the proposers once the state gets there
+	next, err := GetDuties(state, 1)
+	require.NoError(t, err)
+	require.Len(t, next.Proposers, int(params.ChainConfig.SlotsInEpoch))
+	st := state_transition.NewStateTransition()
+	for _, duty := range next.Proposers {
+		advanced := shared.CopyState(state)
+		require.NoError(t, st.ProcessSlots(advanced, duty.Slot))
+		proposer, err := shared.GetBlockProposerIndex(advanced)
+		require.NoError(t, err)
+		require.EqualValues(t, proposer, duty.ValidatorIndex)
+		require.Contains(t, next.ProposerDuties(duty.ValidatorIndex), duty)
+		require.Contains(t, next.ProposerDutiesByPubKey(duty.PublicKey), duty)
+	}
+
+	_, err = GetDuties(state, 2)
+	require.EqualError(t, err, "get duties: epoch 2 not in [0, 1]")
+}
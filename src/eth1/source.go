@@ -0,0 +1,171 @@
+package eth1
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/ghodss/yaml"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Block is an eth1 block with the state of the deposit contract at it
+type Block struct {
+	Hash         []byte
+	Number       uint64
+	Timestamp    uint64
+	DepositRoot  []byte
+	DepositCount uint64
+}
+
+// Eth1Data returns the eth1 data a vote for the block carries
+func (b *Block) Eth1Data() *core.ETH1Data {
+	return &core.ETH1Data{
+		DepositRoot:  b.DepositRoot,
+		DepositCount: b.DepositCount,
+		BlockHash:    b.Hash,
+	}
+}
+
+// DataSource provides the eth1 chain to vote on
+type DataSource interface {
+	// BlocksInTimeRange returns the blocks with from <= timestamp <= to sorted by ascending height
+	BlocksInTimeRange(from uint64, to uint64) ([]*Block, error)
+}
+
+// InMemoryDataSource holds the eth1 chain in memory, blocks must be added by ascending height.
+type InMemoryDataSource struct {
+	lock   sync.RWMutex
+	blocks []*Block
+}
+
+func NewInMemoryDataSource(blocks ...*Block) (*InMemoryDataSource, error) {
+	ret := &InMemoryDataSource{}
+	for _, block := range blocks {
+		if err := ret.AddBlock(block); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// AddBlock appends the next block of the eth1 chain
+func (s *InMemoryDataSource) AddBlock(block *Block) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.blocks) > 0 {
+		last := s.blocks[len(s.blocks)-1]
+		if block.Number <= last.Number || block.Timestamp < last.Timestamp {
+			return fmt.Errorf("eth1 block %d must come after block %d", block.Number, last.Number)
+		}
+		if block.DepositCount < last.DepositCount {
+			return fmt.Errorf("eth1 block %d deposit count can't decrease", block.Number)
+		}
+	}
+	s.blocks = append(s.blocks, block)
+	return nil
+}
+
+func (s *InMemoryDataSource) BlocksInTimeRange(from uint64, to uint64) ([]*Block, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	start := sort.Search(len(s.blocks), func(i int) bool { return s.blocks[i].Timestamp >= from })
+	end := sort.Search(len(s.blocks), func(i int) bool { return s.blocks[i].Timestamp > to })
+	if start >= end {
+		return []*Block{}, nil
+	}
+	ret := make([]*Block, end-start)
+	copy(ret, s.blocks[start:end])
+	return ret, nil
+}
+
+// Blocks returns all the blocks of the source
+func (s *InMemoryDataSource) Blocks() []*Block {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make([]*Block, len(s.blocks))
+	copy(ret, s.blocks)
+	return ret
+}
+
+// a recorded block, byte fields are 0x prefixed hex
+type recordedBlock struct {
+	Hash         string `json:"hash"`
+	Number       uint64 `json:"number"`
+	Timestamp    uint64 `json:"timestamp"`
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount uint64 `json:"deposit_count"`
+}
+
+// NewFileDataSource replays eth1 blocks recorded in a yaml (or json) file, a list of blocks sorted by height
+// with hash, number, timestamp, deposit_root and deposit_count fields.
+func NewFileDataSource(file string) (*InMemoryDataSource, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("eth1 file source: %s", err.Error())
+	}
+	recorded := make([]*recordedBlock, 0)
+	if err := yaml.Unmarshal(byts, &recorded); err != nil {
+		return nil, fmt.Errorf("eth1 file source: %s", err.Error())
+	}
+
+	ret := &InMemoryDataSource{}
+	for _, r := range recorded {
+		hash, err := decodeHex(r.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("eth1 file source: block %d hash: %s", r.Number, err.Error())
+		}
+		depositRoot, err := decodeHex(r.DepositRoot)
+		if err != nil {
+			return nil, fmt.Errorf("eth1 file source: block %d deposit root: %s", r.Number, err.Error())
+		}
+		if err := ret.AddBlock(&Block{
+			Hash:         hash,
+			Number:       r.Number,
+			Timestamp:    r.Timestamp,
+			DepositRoot:  depositRoot,
+			DepositCount: r.DepositCount,
+		}); err != nil {
+			return nil, fmt.Errorf("eth1 file source: %s", err.Error())
+		}
+	}
+	return ret, nil
+}
+
+// WriteBlocks records blocks in the format NewFileDataSource reads
+func WriteBlocks(file string, blocks []*Block) error {
+	recorded := make([]*recordedBlock, len(blocks))
+	for i, b := range blocks {
+		recorded[i] = &recordedBlock{
+			Hash:         "0x" + hex.EncodeToString(b.Hash),
+			Number:       b.Number,
+			Timestamp:    b.Timestamp,
+			DepositRoot:  "0x" + hex.EncodeToString(b.DepositRoot),
+			DepositCount: b.DepositCount,
+		}
+	}
+	byts, err := yaml.Marshal(recorded)
+	if err != nil {
+		return fmt.Errorf("write eth1 blocks: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(file, byts, 0644); err != nil {
+		return fmt.Errorf("write eth1 blocks: %s", err.Error())
+	}
+	return nil
+}
+
+func decodeHex(str string) ([]byte, error) {
+	byts, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(byts) != 32 {
+		return nil, fmt.Errorf("must be 32 bytes")
+	}
+	return byts, nil
+}
@@ -0,0 +1,127 @@
+package eth1
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+)
+
+/**
+def voting_period_start_time(state: BeaconState) -> uint64:
+    eth1_voting_period_start_slot = Slot(state.slot - state.slot % (EPOCHS_PER_ETH1_VOTING_PERIOD * SLOTS_PER_EPOCH))
+    return compute_time_at_slot(state, eth1_voting_period_start_slot)
+ */
+func VotingPeriodStartTime(state *core.State) uint64 {
+	periodSlots := params.ChainConfig.EpochsPerETH1VotingPeriod * params.ChainConfig.SlotsInEpoch
+	return shared.ComputeTimeAtSlot(state, state.Slot-state.Slot%periodSlots)
+}
+
+/**
+def is_candidate_block(block: Eth1Block, period_start: uint64) -> bool:
+    return (
+        block.timestamp + SECONDS_PER_ETH1_BLOCK * ETH1_FOLLOW_DISTANCE <= period_start
+        and block.timestamp + SECONDS_PER_ETH1_BLOCK * ETH1_FOLLOW_DISTANCE * 2 >= period_start
+    )
+ */
+func IsCandidateBlock(block *Block, periodStart uint64) bool {
+	followTime := params.ChainConfig.SecondsPerETH1Block * params.ChainConfig.ETH1FollowDistance
+	return block.Timestamp+followTime <= periodStart && block.Timestamp+followTime*2 >= periodStart
+}
+
+/**
+def get_eth1_vote(state: BeaconState, eth1_chain: Sequence[Eth1Block]) -> Eth1Data:
+    period_start = voting_period_start_time(state)
+    # `eth1_chain` abstractly represents all blocks in the eth1 chain sorted by ascending block height
+    votes_to_consider = [
+        get_eth1_data(block) for block in eth1_chain
+        if (
+            is_candidate_block(block, period_start)
+            # Ensure cannot move back to earlier deposit contract states
+            and get_eth1_data(block).deposit_count >= state.eth1_data.deposit_count
+        )
+    ]
+
+    # Valid votes already cast during this period
+    valid_votes = [vote for vote in state.eth1_data_votes if vote in votes_to_consider]
+
+    # Default vote on latest eth1 block data in the period range unless eth1 chain is not live
+    # Non-substantive casting for linter
+    state_eth1_data: Eth1Data = state.eth1_data
+    default_vote = votes_to_consider[len(votes_to_consider) - 1] if any(votes_to_consider) else state_eth1_data
+
+    return max(
+        valid_votes,
+        key=lambda v: (valid_votes.count(v), -valid_votes.index(v)),  # Tiebreak by smallest distance
+        default=default_vote
+    )
+ */
+// GetEth1Vote returns the eth1 data an honest proposer of a block at state.Slot votes for, the candidate blocks
+// are read from source. The returned eth1 data is a copy, it can be modified without changing state.
+func GetEth1Vote(state *core.State, source DataSource) (*core.ETH1Data, error) {
+	periodStart := VotingPeriodStartTime(state)
+	followTime := params.ChainConfig.SecondsPerETH1Block * params.ChainConfig.ETH1FollowDistance
+	from := uint64(0)
+	if periodStart > followTime*2 {
+		from = periodStart - followTime*2
+	}
+	to := uint64(0)
+	if periodStart >= followTime {
+		to = periodStart - followTime
+	}
+
+	blocks, err := source.BlocksInTimeRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get eth1 vote: %s", err.Error())
+	}
+	votesToConsider := make([]*core.ETH1Data, 0)
+	for _, block := range blocks {
+		// Ensure cannot move back to earlier deposit contract states
+		if IsCandidateBlock(block, periodStart) && block.DepositCount >= state.Eth1Data.DepositCount {
+			votesToConsider = append(votesToConsider, block.Eth1Data())
+		}
+	}
+
+	// Valid votes already cast during this period
+	validVotes := make([]*core.ETH1Data, 0)
+	for _, vote := range state.Eth1DataVotes {
+		for _, candidate := range votesToConsider {
+			if core.ETH1DataEqual(vote, candidate) {
+				validVotes = append(validVotes, vote)
+				break
+			}
+		}
+	}
+
+	// Default vote on latest eth1 block data in the period range unless eth1 chain is not live
+	if len(validVotes) == 0 {
+		if len(votesToConsider) > 0 {
+			return copyEth1Data(votesToConsider[len(votesToConsider)-1]), nil
+		}
+		return copyEth1Data(state.Eth1Data), nil
+	}
+
+	// the most voted, ties are broken by the first cast
+	var ret *core.ETH1Data
+	maxCount := 0
+	for i, vote := range validVotes {
+		count := 0
+		for _, other := range validVotes[i:] {
+			if core.ETH1DataEqual(vote, other) {
+				count++
+			}
+		}
+		if count > maxCount {
+			ret, maxCount = vote, count
+		}
+	}
+	return copyEth1Data(ret), nil
+}
+
+func copyEth1Data(data *core.ETH1Data) *core.ETH1Data {
+	return &core.ETH1Data{
+		DepositRoot:  append([]byte{}, data.DepositRoot...),
+		DepositCount: data.DepositCount,
+		BlockHash:    append([]byte{}, data.BlockHash...),
+	}
+}
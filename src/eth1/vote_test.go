@@ -0,0 +1,119 @@
+package eth1
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func block(number uint64, timestamp uint64, depositCount uint64) *Block {
+	hash := make([]byte, 32)
+	hash[0] = byte(number)
+	depositRoot := make([]byte, 32)
+	depositRoot[0] = byte(depositCount)
+	return &Block{Hash: hash, Number: number, Timestamp: timestamp, DepositRoot: depositRoot, DepositCount: depositCount}
+}
+
+// a state in the second voting period, the genesis time is set so the period starts at periodStart
+func votingState(periodStart uint64, depositCount uint64) *core.State {
+	periodSlots := params.ChainConfig.EpochsPerETH1VotingPeriod * params.ChainConfig.SlotsInEpoch
+	return &core.State{
+		GenesisTime:   periodStart - periodSlots*params.ChainConfig.SecondsPerSlot,
+		Slot:          periodSlots + 3,
+		Eth1Data:      block(0, 0, depositCount).Eth1Data(),
+		Eth1DataVotes: []*core.ETH1Data{},
+	}
+}
+
+func TestGetEth1Vote(t *testing.T) {
+	followTime := params.ChainConfig.SecondsPerETH1Block * params.ChainConfig.ETH1FollowDistance
+	periodStart := 10 * followTime
+	state := votingState(periodStart, 2)
+	require.EqualValues(t, periodStart, VotingPeriodStartTime(state))
+
+	source, err := NewInMemoryDataSource()
+	require.NoError(t, err)
+
+	// no eth1 chain
+	vote, err := GetEth1Vote(state, source)
+	require.NoError(t, err)
+	require.EqualValues(t, state.Eth1Data, vote)
+
+	tooOld := block(1, periodStart-2*followTime-1, 1)
+	// before the state's deposits
+	movesBack := block(2, periodStart-2*followTime, 1)
+	first := block(3, periodStart-2*followTime+1, 2)
+	second := block(4, periodStart-followTime-1, 3)
+	latest := block(5, periodStart-followTime, 3)
+	tooRecent := block(6, periodStart-followTime+1, 4)
+	for _, b := range []*Block{tooOld, movesBack, first, second, latest, tooRecent} {
+		require.NoError(t, source.AddBlock(b))
+	}
+	require.True(t, IsCandidateBlock(movesBack, periodStart))
+	require.False(t, IsCandidateBlock(tooOld, periodStart))
+	require.False(t, IsCandidateBlock(tooRecent, periodStart))
+
+	// no votes, the latest candidate
+	vote, err = GetEth1Vote(state, source)
+	require.NoError(t, err)
+	require.EqualValues(t, latest.Eth1Data(), vote)
+
+	// votes for blocks that are not candidates are ignored
+	state.Eth1DataVotes = []*core.ETH1Data{tooRecent.Eth1Data(), tooRecent.Eth1Data(), movesBack.Eth1Data(), second.Eth1Data()}
+	vote, err = GetEth1Vote(state, source)
+	require.NoError(t, err)
+	require.EqualValues(t, second.Eth1Data(), vote)
+
+	// the most voted
+	state.Eth1DataVotes = append(state.Eth1DataVotes, first.Eth1Data(), first.Eth1Data())
+	vote, err = GetEth1Vote(state, source)
+	require.NoError(t, err)
+	require.EqualValues(t, first.Eth1Data(), vote)
+
+	// ties are broken by the first vote
+	state.Eth1DataVotes = append(state.Eth1DataVotes, second.Eth1Data())
+	vote, err = GetEth1Vote(state, source)
+	require.NoError(t, err)
+	require.EqualValues(t, second.Eth1Data(), vote)
+
+	// the vote is a copy
+	vote.BlockHash[0]++
+	vote.DepositCount++
+	require.EqualValues(t, second.Eth1Data(), state.Eth1DataVotes[3])
+	state.Eth1DataVotes = nil
+	source, err = NewInMemoryDataSource()
+	require.NoError(t, err)
+	vote, err = GetEth1Vote(state, source)
+	require.NoError(t, err)
+	vote.DepositRoot[0]++
+	require.NotEqual(t, vote.DepositRoot, state.Eth1Data.DepositRoot)
+}
+
+func TestFileDataSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eth1")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "blocks.yaml")
+
+	blocks := []*Block{block(1, 100, 0), block(2, 114, 1), block(3, 128, 1)}
+	require.NoError(t, WriteBlocks(file, blocks))
+	source, err := NewFileDataSource(file)
+	require.NoError(t, err)
+	require.EqualValues(t, blocks, source.Blocks())
+
+	inRange, err := source.BlocksInTimeRange(101, 128)
+	require.NoError(t, err)
+	require.EqualValues(t, blocks[1:], inRange)
+	inRange, err = source.BlocksInTimeRange(129, 200)
+	require.NoError(t, err)
+	require.Len(t, inRange, 0)
+
+	// blocks must be sorted by height
+	require.NoError(t, WriteBlocks(file, []*Block{blocks[1], blocks[0]}))
+	_, err = NewFileDataSource(file)
+	require.EqualError(t, err, "eth1 file source: eth1 block 1 must come after block 2")
+}
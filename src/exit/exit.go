@@ -0,0 +1,68 @@
+package exit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"strconv"
+)
+
+// NewSignedVoluntaryExit returns the voluntary exit of validatorIndex at epoch signed by keySigner. The exit is checked
+// against state as process_voluntary_exit does, so an exit returned for the head state is valid in the next block.
+func NewSignedVoluntaryExit(state *core.State, validatorIndex uint64, epoch uint64, keySigner signer.Signer) (*core.SignedVoluntaryExit, error) {
+	validator := shared.GetValidator(state, validatorIndex)
+	if validator == nil {
+		return nil, fmt.Errorf("voluntary exit: validator %d not found", validatorIndex)
+	}
+	currentEpoch := shared.GetCurrentEpoch(state)
+	if !shared.IsActiveValidator(validator, currentEpoch) {
+		return nil, fmt.Errorf("voluntary exit: validator %d not active", validatorIndex)
+	}
+	if validator.ExitEpoch != params.ChainConfig.FarFutureEpoch {
+		return nil, fmt.Errorf("voluntary exit: validator %d has started exit", validatorIndex)
+	}
+	if epoch > currentEpoch {
+		return nil, fmt.Errorf("voluntary exit: epoch %d after the current epoch %d", epoch, currentEpoch)
+	}
+	if eligible := validator.ActivationEpoch + params.ChainConfig.ShardCommitteePeriod; currentEpoch < eligible {
+		return nil, fmt.Errorf("voluntary exit: validator %d can exit from epoch %d (SHARD_COMMITTEE_PERIOD after activation)", validatorIndex, eligible)
+	}
+
+	msg := &core.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorIndex,
+	}
+	sig, err := keySigner.SignVoluntaryExit(state, msg, validator.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("voluntary exit: %s", err.Error())
+	}
+	return &core.SignedVoluntaryExit{
+		Exit:      msg,
+		Signature: sig,
+	}, nil
+}
+
+type signedVoluntaryExitJSON struct {
+	Message   *voluntaryExitJSON `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type voluntaryExitJSON struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// MarshalJSON encodes exit as the beacon node API's POST /eth/v1/beacon/pool/voluntary_exits body
+func MarshalJSON(exit *core.SignedVoluntaryExit) ([]byte, error) {
+	return json.MarshalIndent(&signedVoluntaryExitJSON{
+		Message: &voluntaryExitJSON{
+			Epoch:          strconv.FormatUint(exit.Exit.Epoch, 10),
+			ValidatorIndex: strconv.FormatUint(exit.Exit.ValidatorIndex, 10),
+		},
+		Signature: "0x" + hex.EncodeToString(exit.Signature),
+	}, "", "  ")
+}
@@ -0,0 +1,49 @@
+package exit
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/genesis"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSignedVoluntaryExit(t *testing.T) {
+	state, keys, err := genesis.InteropGenesisState(0, params.ChainConfig.MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+	localSigner, err := signer.NewLocalSigner()
+	require.NoError(t, err)
+	for _, key := range keys {
+		_, err := localSigner.AddKey(key.Serialize())
+		require.NoError(t, err)
+	}
+
+	// genesis validators can exit SHARD_COMMITTEE_PERIOD epochs after genesis
+	_, err = NewSignedVoluntaryExit(state, 1, 0, localSigner)
+	require.EqualError(t, err, fmt.Sprintf("voluntary exit: validator 1 can exit from epoch %d (SHARD_COMMITTEE_PERIOD after activation)", params.ChainConfig.ShardCommitteePeriod))
+
+	state.Slot = shared.ComputeStartSlotAtEpoch(params.ChainConfig.ShardCommitteePeriod)
+	signed, err := NewSignedVoluntaryExit(state, 1, params.ChainConfig.ShardCommitteePeriod, localSigner)
+	require.NoError(t, err)
+	require.NoError(t, state_transition.ProcessVoluntaryExit(state, signed))
+	require.NotEqual(t, params.ChainConfig.FarFutureEpoch, state.Validators[1].ExitEpoch)
+
+	// an exit signed for an earlier epoch is valid too
+	signed, err = NewSignedVoluntaryExit(state, 2, 0, localSigner)
+	require.NoError(t, err)
+	require.NoError(t, state_transition.ProcessVoluntaryExit(state, signed))
+
+	byts, err := MarshalJSON(signed)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"message":{"epoch":"0","validator_index":"2"},"signature":"0x%x"}`, signed.Signature), string(byts))
+
+	_, err = NewSignedVoluntaryExit(state, 1, params.ChainConfig.ShardCommitteePeriod, localSigner)
+	require.EqualError(t, err, "voluntary exit: validator 1 has started exit")
+	_, err = NewSignedVoluntaryExit(state, 3, params.ChainConfig.ShardCommitteePeriod+1, localSigner)
+	require.EqualError(t, err, fmt.Sprintf("voluntary exit: epoch %d after the current epoch %d", params.ChainConfig.ShardCommitteePeriod+1, params.ChainConfig.ShardCommitteePeriod))
+	_, err = NewSignedVoluntaryExit(state, uint64(len(state.Validators)), 0, localSigner)
+	require.EqualError(t, err, fmt.Sprintf("voluntary exit: validator %d not found", len(state.Validators)))
+}
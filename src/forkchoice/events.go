@@ -0,0 +1,198 @@
+package forkchoice
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+type HeadChangeEvent struct {
+	OldHead []byte
+	NewHead []byte
+	// nil if the old head was pruned from the store
+	CommonAncestor []byte
+	// number of blocks of the old head's chain which are no longer canonical, 0 if the new head descends from the old one
+	ReorgDepth uint64
+}
+
+func (e *HeadChangeEvent) IsReorg() bool {
+	return e.ReorgDepth > 0
+}
+
+type CheckpointEvent struct {
+	Previous   *core.Checkpoint
+	Checkpoint *core.Checkpoint
+}
+
+type JustifiedCheckpointEvent struct {
+	CheckpointEvent
+}
+
+type FinalizedCheckpointEvent struct {
+	CheckpointEvent
+}
+
+// IEventHandler is notified of fork choice changes. Handlers are called after the store's lock is released,
+// in the order the changes happened, so they can query the store.
+//
+// Checkpoint changes are reported as soon as they happen, the head is re-evaluated on OnTick, OnBlock,
+// OnAttesterSlashing, GetHead and BlockTree calls, attestations are accounted for on the next of those.
+type IEventHandler interface {
+	OnHeadChange(event *HeadChangeEvent)
+	OnJustifiedCheckpoint(event *JustifiedCheckpointEvent)
+	OnFinalizedCheckpoint(event *FinalizedCheckpointEvent)
+}
+
+// EventChannel delivers events to C as *HeadChangeEvent, *JustifiedCheckpointEvent and *FinalizedCheckpointEvent.
+// Sends block the store's caller until the event is received or the channel has room.
+type EventChannel struct {
+	C chan interface{}
+}
+
+func NewEventChannel(size int) *EventChannel {
+	return &EventChannel{C: make(chan interface{}, size)}
+}
+
+func (c *EventChannel) OnHeadChange(event *HeadChangeEvent) {
+	c.C <- event
+}
+
+func (c *EventChannel) OnJustifiedCheckpoint(event *JustifiedCheckpointEvent) {
+	c.C <- event
+}
+
+func (c *EventChannel) OnFinalizedCheckpoint(event *FinalizedCheckpointEvent) {
+	c.C <- event
+}
+
+type subscriber struct {
+	id      uint64
+	handler IEventHandler
+}
+
+// Subscribe registers the handler until the returned func is called
+func (s *Store) Subscribe(handler IEventHandler) func() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// start from the current head so the first event doesn't report a stale one
+	if len(s.subscribers) == 0 {
+		if head, err := s.backend.findHead(s); err == nil {
+			s.head = head
+		}
+	}
+	id := s.nextSubscriberID
+	s.nextSubscriberID++
+	s.subscribers = append(s.subscribers, &subscriber{id: id, handler: handler})
+
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		for i, sub := range s.subscribers {
+			if sub.id == id {
+				s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// records the checkpoints before an update, the returned func queues events for the checkpoints that
+// changed and re-evaluates the head. Must be called with the lock held.
+func (s *Store) trackChanges() func() {
+	justified := s.justifiedCheckpoint
+	finalized := s.finalizedCheckpoint
+	return func() {
+		if len(s.subscribers) == 0 {
+			return
+		}
+		if !core.CheckpointsEqual(justified, s.justifiedCheckpoint) {
+			s.pendingEvents = append(s.pendingEvents, &JustifiedCheckpointEvent{CheckpointEvent{
+				Previous:   copyCheckpoint(justified),
+				Checkpoint: copyCheckpoint(s.justifiedCheckpoint),
+			}})
+		}
+		if !core.CheckpointsEqual(finalized, s.finalizedCheckpoint) {
+			s.pendingEvents = append(s.pendingEvents, &FinalizedCheckpointEvent{CheckpointEvent{
+				Previous:   copyCheckpoint(finalized),
+				Checkpoint: copyCheckpoint(s.finalizedCheckpoint),
+			}})
+		}
+		// a failing head computation is returned by GetHead, it doesn't fail the update
+		if head, err := s.backend.findHead(s); err == nil {
+			s.updateHead(head)
+		}
+	}
+}
+
+// queues a head change event if the head changed. Must be called with the lock held.
+func (s *Store) updateHead(head [32]byte) {
+	if head == s.head {
+		return
+	}
+	oldHead := s.head
+	s.head = head
+	if len(s.subscribers) == 0 {
+		return
+	}
+
+	event := &HeadChangeEvent{OldHead: copyRoot(oldHead), NewHead: copyRoot(head)}
+	if ancestor, depth, err := s.commonAncestor(oldHead, head); err == nil {
+		event.CommonAncestor = copyRoot(ancestor)
+		event.ReorgDepth = depth
+	}
+	s.pendingEvents = append(s.pendingEvents, event)
+}
+
+// returns the common ancestor of the two blocks and the number of blocks from oldHead to it
+func (s *Store) commonAncestor(oldHead [32]byte, newHead [32]byte) ([32]byte, uint64, error) {
+	depth := uint64(0)
+	for oldHead != newHead {
+		oldBlock, ok := s.blocks[oldHead]
+		if !ok {
+			return [32]byte{}, 0, fmt.Errorf("block %x not found", oldHead)
+		}
+		newBlock, ok := s.blocks[newHead]
+		if !ok {
+			return [32]byte{}, 0, fmt.Errorf("block %x not found", newHead)
+		}
+		if oldBlock.Slot >= newBlock.Slot {
+			oldHead = bytesutil.ToBytes32(oldBlock.ParentRoot)
+			depth++
+		} else {
+			newHead = bytesutil.ToBytes32(newBlock.ParentRoot)
+		}
+	}
+	return oldHead, depth, nil
+}
+
+// dispatches the queued events, must be called without the lock held
+func (s *Store) publishEvents() {
+	s.lock.Lock()
+	events := s.pendingEvents
+	s.pendingEvents = nil
+	handlers := make([]IEventHandler, 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		handlers = append(handlers, sub.handler)
+	}
+	s.lock.Unlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			switch e := event.(type) {
+			case *HeadChangeEvent:
+				handler.OnHeadChange(e)
+			case *JustifiedCheckpointEvent:
+				handler.OnJustifiedCheckpoint(e)
+			case *FinalizedCheckpointEvent:
+				handler.OnFinalizedCheckpoint(e)
+			}
+		}
+	}
+}
+
+func copyRoot(root [32]byte) []byte {
+	ret := make([]byte, 32)
+	copy(ret, root[:])
+	return ret
+}
@@ -0,0 +1,110 @@
+package forkchoice
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type recordingHandler struct {
+	heads     []*HeadChangeEvent
+	justified []*JustifiedCheckpointEvent
+	finalized []*FinalizedCheckpointEvent
+}
+
+func (h *recordingHandler) OnHeadChange(event *HeadChangeEvent) {
+	h.heads = append(h.heads, event)
+}
+
+func (h *recordingHandler) OnJustifiedCheckpoint(event *JustifiedCheckpointEvent) {
+	h.justified = append(h.justified, event)
+}
+
+func (h *recordingHandler) OnFinalizedCheckpoint(event *FinalizedCheckpointEvent) {
+	h.finalized = append(h.finalized, event)
+}
+
+func TestHeadChangeEvents(t *testing.T) {
+	state := genesisState()
+	blockA, stateA := produceBlock(t, state, 1, 'a')
+	blockB, stateB := produceBlock(t, state, 1, 'b')
+
+	for _, newStore := range []func(*core.State) (*Store, error){NewStore, NewProtoArrayStore} {
+		store, err := newStore(state)
+		require.NoError(t, err)
+		genesisRoot := store.JustifiedCheckpoint().Root
+
+		handler := &recordingHandler{}
+		unsubscribe := store.Subscribe(handler)
+		channel := NewEventChannel(10)
+		store.Subscribe(channel)
+
+		// the chain is extended
+		store.OnTick(state.GenesisTime + 2*params.ChainConfig.SecondsPerSlot)
+		require.NoError(t, store.OnBlock(blockA))
+		require.Len(t, handler.heads, 1)
+		require.EqualValues(t, genesisRoot, handler.heads[0].OldHead)
+		require.EqualValues(t, blockRoot(t, blockA), handler.heads[0].NewHead)
+		require.EqualValues(t, genesisRoot, handler.heads[0].CommonAncestor)
+		require.False(t, handler.heads[0].IsReorg())
+		require.EqualValues(t, handler.heads[0], <-channel.C)
+
+		// a sibling wins the tie break or not
+		require.NoError(t, store.OnBlock(blockB))
+		head, err := store.GetHead()
+		require.NoError(t, err)
+		loser, loserState := blockA, stateA
+		if string(head) == string(blockRoot(t, blockA)) {
+			require.Len(t, handler.heads, 1)
+			loser, loserState = blockB, stateB
+		} else {
+			require.Len(t, handler.heads, 2)
+			require.EqualValues(t, 1, handler.heads[1].ReorgDepth)
+			<-channel.C
+		}
+
+		// votes for the other sibling reorg the head
+		require.NoError(t, store.OnAttestation(produceAttestation(t, loserState, 1, blockRoot(t, loser), genesisRoot)))
+		newHead, err := store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, loser), newHead)
+		event := handler.heads[len(handler.heads)-1]
+		require.EqualValues(t, head, event.OldHead)
+		require.EqualValues(t, newHead, event.NewHead)
+		require.EqualValues(t, genesisRoot, event.CommonAncestor)
+		require.EqualValues(t, 1, event.ReorgDepth)
+		require.True(t, event.IsReorg())
+		require.EqualValues(t, event, <-channel.C)
+
+		// no events once unsubscribed
+		unsubscribe()
+		count := len(handler.heads)
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateA, 1, blockRoot(t, blockA), genesisRoot)))
+		_, err = store.GetHead()
+		require.NoError(t, err)
+		require.Len(t, handler.heads, count)
+	}
+}
+
+func TestJustifiedCheckpointEvent(t *testing.T) {
+	state := genesisState()
+	block1, _ := produceBlock(t, state, 1, 0)
+	store, err := NewStore(state)
+	require.NoError(t, err)
+	genesisRoot := store.JustifiedCheckpoint().Root
+
+	handler := &recordingHandler{}
+	store.Subscribe(handler)
+	store.OnTick(state.GenesisTime + params.ChainConfig.SecondsPerSlot)
+	require.NoError(t, store.OnBlock(block1))
+
+	// a better justified checkpoint is promoted at the epoch boundary
+	store.bestJustifiedCheckpoint = &core.Checkpoint{Epoch: 1, Root: blockRoot(t, block1)}
+	store.OnTick(state.GenesisTime + params.ChainConfig.SlotsInEpoch*params.ChainConfig.SecondsPerSlot)
+	require.Len(t, handler.justified, 1)
+	require.EqualValues(t, genesisRoot, handler.justified[0].Previous.Root)
+	require.EqualValues(t, 1, handler.justified[0].Checkpoint.Epoch)
+	require.EqualValues(t, blockRoot(t, block1), handler.justified[0].Checkpoint.Root)
+	require.Len(t, handler.finalized, 0)
+}
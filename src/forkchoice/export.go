@@ -0,0 +1,146 @@
+package forkchoice
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"io"
+	"sort"
+)
+
+type BlockTreeNode struct {
+	Root          string `json:"root"`
+	ParentRoot    string `json:"parent_root"`
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	Weight        uint64 `json:"weight"`
+	Justified     bool   `json:"justified"`
+	Finalized     bool   `json:"finalized"`
+	// the node is the head or one of its ancestors
+	HeadPath bool `json:"head_path"`
+}
+
+// BlockTree is a snapshot of the blocks known to the fork choice store, used for debugging forks.
+type BlockTree struct {
+	Head      string           `json:"head"`
+	Justified string           `json:"justified"`
+	Finalized string           `json:"finalized"`
+	Nodes     []*BlockTreeNode `json:"nodes"`
+}
+
+// BlockTree computes the head and returns every block of the store with its fork choice weight,
+// nodes are sorted by slot and root.
+func (s *Store) BlockTree() (*BlockTree, error) {
+	defer s.publishEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	head, err := s.backend.findHead(s)
+	if err != nil {
+		return nil, fmt.Errorf("block tree: %s", err.Error())
+	}
+	s.updateHead(head)
+	weights, err := s.backend.weights(s)
+	if err != nil {
+		return nil, fmt.Errorf("block tree: %s", err.Error())
+	}
+
+	headPath := make(map[[32]byte]bool)
+	for root := head; ; {
+		block, ok := s.blocks[root]
+		if !ok {
+			break
+		}
+		headPath[root] = true
+		root = bytesutil.ToBytes32(block.ParentRoot)
+	}
+
+	justified := bytesutil.ToBytes32(s.justifiedCheckpoint.Root)
+	finalized := bytesutil.ToBytes32(s.finalizedCheckpoint.Root)
+	nodes := make([]*BlockTreeNode, 0, len(s.blocks))
+	for root, block := range s.blocks {
+		nodes = append(nodes, &BlockTreeNode{
+			Root:          encodeRoot(root[:]),
+			ParentRoot:    encodeRoot(block.ParentRoot),
+			Slot:          block.Slot,
+			ProposerIndex: block.ProposerIndex,
+			Weight:        weights[root],
+			Justified:     root == justified,
+			Finalized:     root == finalized,
+			HeadPath:      headPath[root],
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Slot != nodes[j].Slot {
+			return nodes[i].Slot < nodes[j].Slot
+		}
+		return nodes[i].Root < nodes[j].Root
+	})
+
+	return &BlockTree{
+		Head:      encodeRoot(head[:]),
+		Justified: encodeRoot(justified[:]),
+		Finalized: encodeRoot(finalized[:]),
+		Nodes:     nodes,
+	}, nil
+}
+
+func (t *BlockTree) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// DOT returns a Graphviz digraph with an edge from every block to its parent. Blocks on the head path
+// are filled, justified and finalized blocks are marked with J/F in their label.
+func (t *BlockTree) DOT() string {
+	buf := &bytes.Buffer{}
+	_ = t.WriteDOT(buf)
+	return buf.String()
+}
+
+func (t *BlockTree) WriteDOT(w io.Writer) error {
+	known := make(map[string]bool, len(t.Nodes))
+	for _, node := range t.Nodes {
+		known[node.Root] = true
+	}
+
+	if _, err := fmt.Fprintln(w, "digraph BlockTree {\n  rankdir=RL;\n  node [shape=box, style=filled, fillcolor=white];"); err != nil {
+		return err
+	}
+	for _, node := range t.Nodes {
+		markers := ""
+		if node.Justified {
+			markers += " J"
+		}
+		if node.Finalized {
+			markers += " F"
+		}
+		attrs := ""
+		if node.HeadPath {
+			attrs += ", fillcolor=lightblue"
+		}
+		if node.Root == t.Head {
+			attrs += ", penwidth=3"
+		}
+		label := fmt.Sprintf("slot %d%s\\nproposer %d\\nroot %s\\nweight %d", node.Slot, markers, node.ProposerIndex, node.Root[:10], node.Weight)
+		if _, err := fmt.Fprintf(w, "  \"%s\" [label=\"%s\"%s];\n", node.Root, label, attrs); err != nil {
+			return err
+		}
+	}
+	for _, node := range t.Nodes {
+		// pruned or anchor parents are not part of the tree
+		if !known[node.ParentRoot] {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "  \"%s\" -> \"%s\";\n", node.Root, node.ParentRoot); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func encodeRoot(root []byte) string {
+	return "0x" + hex.EncodeToString(root)
+}
@@ -0,0 +1,64 @@
+package forkchoice
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestBlockTree(t *testing.T) {
+	state := genesisState()
+	blockA, stateA := produceBlock(t, state, 1, 'a')
+	blockB, _ := produceBlock(t, state, 2, 'b')
+	committee, err := shared.GetBeaconCommittee(stateA, 1, 0)
+	require.NoError(t, err)
+
+	for _, newStore := range []func(*core.State) (*Store, error){NewStore, NewProtoArrayStore} {
+		store, err := newStore(state)
+		require.NoError(t, err)
+		genesisRoot := store.JustifiedCheckpoint().Root
+
+		store.OnTick(state.GenesisTime + 3*params.ChainConfig.SecondsPerSlot)
+		require.NoError(t, store.OnBlock(blockA))
+		require.NoError(t, store.OnBlock(blockB))
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateA, 1, blockRoot(t, blockA), genesisRoot)))
+
+		tree, err := store.BlockTree()
+		require.NoError(t, err)
+		require.EqualValues(t, encodeRoot(blockRoot(t, blockA)), tree.Head)
+		require.Len(t, tree.Nodes, 3)
+
+		genesis, a, b := tree.Nodes[0], tree.Nodes[1], tree.Nodes[2]
+		require.EqualValues(t, encodeRoot(genesisRoot), genesis.Root)
+		require.True(t, genesis.Justified && genesis.Finalized && genesis.HeadPath)
+
+		require.EqualValues(t, encodeRoot(blockRoot(t, blockA)), a.Root)
+		require.EqualValues(t, genesis.Root, a.ParentRoot)
+		require.EqualValues(t, 1, a.Slot)
+		require.EqualValues(t, blockA.Block.Proposer, a.ProposerIndex)
+		require.EqualValues(t, uint64(len(committee))*params.ChainConfig.MaxEffectiveBalance, a.Weight)
+		require.True(t, a.HeadPath)
+		require.False(t, a.Justified || a.Finalized)
+
+		require.EqualValues(t, 0, b.Weight)
+		require.False(t, b.HeadPath)
+
+		// JSON round trip
+		byts, err := tree.JSON()
+		require.NoError(t, err)
+		decoded := &BlockTree{}
+		require.NoError(t, json.Unmarshal(byts, decoded))
+		require.EqualValues(t, tree, decoded)
+
+		dot := tree.DOT()
+		require.True(t, strings.HasPrefix(dot, "digraph BlockTree {"))
+		require.Contains(t, dot, fmt.Sprintf("\"%s\" -> \"%s\";", a.Root, genesis.Root))
+		require.Contains(t, dot, fmt.Sprintf("\"%s\" -> \"%s\";", b.Root, genesis.Root))
+		require.Contains(t, dot, "slot 0 J F")
+	}
+}
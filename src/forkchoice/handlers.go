@@ -0,0 +1,388 @@
+package forkchoice
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+func (s *Store) OnTick(time uint64) {
+	defer s.publishEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer s.trackChanges()()
+
+	previousSlot := s.currentSlot()
+
+	// update store time
+	s.time = time
+
+	currentSlot := s.currentSlot()
+
+	// Reset store.proposer_boost_root if this is a new slot
+	if currentSlot > previousSlot {
+		s.proposerBoostRoot = [32]byte{}
+	}
+
+	// Not a new epoch, return
+	if !(currentSlot > previousSlot && computeSlotsSinceEpochStart(currentSlot) == 0) {
+		return
+	}
+	// Update store.justified_checkpoint if a better checkpoint is known
+	if s.bestJustifiedCheckpoint.Epoch > s.justifiedCheckpoint.Epoch {
+		s.justifiedCheckpoint = copyCheckpoint(s.bestJustifiedCheckpoint)
+	}
+}
+
+func (s *Store) OnBlock(signedBlock *core.SignedBlock) error {
+	defer s.publishEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer s.trackChanges()()
+
+	block := signedBlock.Block
+	parentRoot := bytesutil.ToBytes32(block.ParentRoot)
+
+	// Parent block must be known
+	parentState, ok := s.blockStates[parentRoot]
+	if !ok {
+		return fmt.Errorf("on block: parent block %x not found", block.ParentRoot)
+	}
+	// Blocks cannot be in the future. If they are, their consideration must be delayed until the are in the past.
+	if s.currentSlot() < block.Slot {
+		return fmt.Errorf("on block: block slot %d is in the future", block.Slot)
+	}
+
+	// Check that block is later than the finalized epoch slot (optimization to reduce calls to get_ancestor)
+	finalizedSlot := shared.ComputeStartSlotAtEpoch(s.finalizedCheckpoint.Epoch)
+	if block.Slot <= finalizedSlot {
+		return fmt.Errorf("on block: block slot %d is not later than the finalized slot", block.Slot)
+	}
+	// Check block is a descendant of the finalized block at the checkpoint finalized slot
+	ancestor, err := s.getAncestor(parentRoot, finalizedSlot)
+	if err != nil {
+		return fmt.Errorf("on block: %s", err.Error())
+	}
+	if ancestor != bytesutil.ToBytes32(s.finalizedCheckpoint.Root) {
+		return fmt.Errorf("on block: block is not a descendant of the finalized checkpoint")
+	}
+
+	// Check the block is valid and compute the post-state
+	state, err := s.st.ExecuteStateTransition(parentState, signedBlock, true)
+	if err != nil {
+		return fmt.Errorf("on block: %s", err.Error())
+	}
+
+	bodyRoot, err := block.Body.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("on block: %s", err.Error())
+	}
+	header := &core.BlockHeader{
+		Slot:          block.Slot,
+		ProposerIndex: block.Proposer,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		BodyRoot:      bodyRoot[:],
+	}
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("on block: %s", err.Error())
+	}
+	// Add new block and its post state to the store, the backend goes first so a rejected block leaves no trace
+	if err := s.backend.processBlock(root, header, state.CurrentJustifiedCheckpoint.Epoch, state.FinalizedCheckpoint.Epoch); err != nil {
+		return fmt.Errorf("on block: %s", err.Error())
+	}
+	s.blocks[root] = header
+	s.blockStates[root] = state
+
+	// Add proposer score boost if the block is timely
+	timeIntoSlot := (s.time - s.genesisTime) % params.ChainConfig.SecondsPerSlot
+	isBeforeAttestingInterval := timeIntoSlot < params.ChainConfig.SecondsPerSlot/params.ChainConfig.IntervalsPerSlot
+	if s.currentSlot() == block.Slot && isBeforeAttestingInterval {
+		s.proposerBoostRoot = root
+	}
+
+	// Update justified checkpoint
+	if state.CurrentJustifiedCheckpoint.Epoch > s.justifiedCheckpoint.Epoch {
+		if state.CurrentJustifiedCheckpoint.Epoch > s.bestJustifiedCheckpoint.Epoch {
+			s.bestJustifiedCheckpoint = copyCheckpoint(state.CurrentJustifiedCheckpoint)
+		}
+		shouldUpdate, err := s.shouldUpdateJustifiedCheckpoint(state.CurrentJustifiedCheckpoint)
+		if err != nil {
+			return fmt.Errorf("on block: %s", err.Error())
+		}
+		if shouldUpdate {
+			s.justifiedCheckpoint = copyCheckpoint(state.CurrentJustifiedCheckpoint)
+		}
+	}
+
+	// Update finalized checkpoint
+	if state.FinalizedCheckpoint.Epoch > s.finalizedCheckpoint.Epoch {
+		s.finalizedCheckpoint = copyCheckpoint(state.FinalizedCheckpoint)
+
+		// Potentially update justified if different from store
+		if !core.CheckpointsEqual(s.justifiedCheckpoint, state.CurrentJustifiedCheckpoint) {
+			// Update justified if new justified is later than store justified
+			if state.CurrentJustifiedCheckpoint.Epoch > s.justifiedCheckpoint.Epoch {
+				s.justifiedCheckpoint = copyCheckpoint(state.CurrentJustifiedCheckpoint)
+			} else {
+				// Update justified if store justified is not in chain with finalized checkpoint
+				finalizedSlot := shared.ComputeStartSlotAtEpoch(s.finalizedCheckpoint.Epoch)
+				ancestor, err := s.getAncestor(bytesutil.ToBytes32(s.justifiedCheckpoint.Root), finalizedSlot)
+				if err != nil {
+					return fmt.Errorf("on block: %s", err.Error())
+				}
+				if ancestor != bytesutil.ToBytes32(s.finalizedCheckpoint.Root) {
+					s.justifiedCheckpoint = copyCheckpoint(state.CurrentJustifiedCheckpoint)
+				}
+			}
+		}
+
+		if err := s.pruneFinalized(); err != nil {
+			return fmt.Errorf("on block: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// drops the blocks and states the backend pruned and checkpoint states older than the finalized checkpoint
+func (s *Store) pruneFinalized() error {
+	pruned, err := s.backend.prune(bytesutil.ToBytes32(s.finalizedCheckpoint.Root))
+	if err != nil {
+		return err
+	}
+	for _, root := range pruned {
+		delete(s.blocks, root)
+		delete(s.blockStates, root)
+	}
+	for key := range s.checkpointStates {
+		if key.epoch < s.finalizedCheckpoint.Epoch {
+			delete(s.checkpointStates, key)
+		}
+	}
+	return nil
+}
+
+func (s *Store) OnAttestation(attestation *core.Attestation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.validateOnAttestation(attestation); err != nil {
+		return fmt.Errorf("on attestation: %s", err.Error())
+	}
+	targetState, err := s.storeTargetCheckpointState(attestation.Data.Target)
+	if err != nil {
+		return fmt.Errorf("on attestation: %s", err.Error())
+	}
+
+	// Get state at the `target` to fully validate attestation
+	indexedAttestation, err := shared.GetIndexedAttestation(targetState, attestation)
+	if err != nil {
+		return fmt.Errorf("on attestation: %s", err.Error())
+	}
+	if res, err := shared.IsValidIndexedAttestation(targetState, indexedAttestation); !res || err != nil {
+		if err != nil {
+			return fmt.Errorf("on attestation: %s", err.Error())
+		}
+		return fmt.Errorf("on attestation: indexed attestation not valid")
+	}
+
+	// Update latest messages for attesting indices
+	s.updateLatestMessages(indexedAttestation.AttestingIndices, attestation)
+	return nil
+}
+
+func (s *Store) OnAttesterSlashing(slashing *core.AttesterSlashing) error {
+	defer s.publishEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer s.trackChanges()()
+
+	attestation1 := slashing.Attestation_1
+	attestation2 := slashing.Attestation_2
+	if !shared.IsSlashableAttestationData(attestation1.Data, attestation2.Data) {
+		return fmt.Errorf("on attester slashing: attestation data not slashable")
+	}
+	state, ok := s.blockStates[bytesutil.ToBytes32(s.justifiedCheckpoint.Root)]
+	if !ok {
+		return fmt.Errorf("on attester slashing: justified block state not found")
+	}
+	for _, attestation := range []*core.IndexedAttestation{attestation1, attestation2} {
+		if res, err := shared.IsValidIndexedAttestation(state, attestation); !res || err != nil {
+			if err != nil {
+				return fmt.Errorf("on attester slashing: %s", err.Error())
+			}
+			return fmt.Errorf("on attester slashing: indexed attestation not valid")
+		}
+	}
+
+	indices := make(map[uint64]bool)
+	for _, index := range attestation1.AttestingIndices {
+		indices[index] = true
+	}
+	for _, index := range attestation2.AttestingIndices {
+		if indices[index] {
+			s.equivocatingIndices[index] = true
+		}
+	}
+	return nil
+}
+
+/**
+def should_update_justified_checkpoint(store: Store, new_justified_checkpoint: Checkpoint) -> bool:
+    """
+    To address the bouncing attack, only update conflicting justified
+    checkpoints in the fork choice if in the early slots of the epoch.
+    Otherwise, delay incorporation of new justified checkpoint until next epoch boundary.
+
+    See https://ethresear.ch/t/prevention-of-bouncing-attack-on-ffg/6114 for more detailed analysis and discussion.
+    """
+    if compute_slots_since_epoch_start(get_current_slot(store)) < SAFE_SLOTS_TO_UPDATE_JUSTIFIED:
+        return True
+
+    justified_slot = compute_start_slot_at_epoch(store.justified_checkpoint.epoch)
+    if not get_ancestor(store, new_justified_checkpoint.root, justified_slot) == store.justified_checkpoint.root:
+        return False
+
+    return True
+ */
+func (s *Store) shouldUpdateJustifiedCheckpoint(newJustifiedCheckpoint *core.Checkpoint) (bool, error) {
+	if computeSlotsSinceEpochStart(s.currentSlot()) < params.ChainConfig.SafeSlotsToUpdateJustified {
+		return true, nil
+	}
+
+	justifiedSlot := shared.ComputeStartSlotAtEpoch(s.justifiedCheckpoint.Epoch)
+	ancestor, err := s.getAncestor(bytesutil.ToBytes32(newJustifiedCheckpoint.Root), justifiedSlot)
+	if err != nil {
+		return false, err
+	}
+	return ancestor == bytesutil.ToBytes32(s.justifiedCheckpoint.Root), nil
+}
+
+/**
+def validate_on_attestation(store: Store, attestation: Attestation) -> None:
+    target = attestation.data.target
+
+    # Attestations must be from the current or previous epoch
+    current_epoch = compute_epoch_at_slot(get_current_slot(store))
+    # Use GENESIS_EPOCH for previous when genesis to avoid underflow
+    previous_epoch = current_epoch - 1 if current_epoch > GENESIS_EPOCH else GENESIS_EPOCH
+    # If attestation target is from a future epoch, delay consideration until the epoch arrives
+    assert target.epoch in [current_epoch, previous_epoch]
+    assert target.epoch == compute_epoch_at_slot(attestation.data.slot)
+
+    # Attestations target be for a known block. If target block is unknown, delay consideration until the block is found
+    assert target.root in store.blocks
+
+    # Attestations must be for a known block. If block is unknown, delay consideration until the block is found
+    assert attestation.data.beacon_block_root in store.blocks
+    # Attestations must not be for blocks in the future. If not, the attestation should not be considered
+    assert store.blocks[attestation.data.beacon_block_root].slot <= attestation.data.slot
+
+    # LMD vote must be consistent with FFG vote target
+    target_slot = compute_start_slot_at_epoch(target.epoch)
+    assert target.root == get_ancestor(store, attestation.data.beacon_block_root, target_slot)
+
+    # Attestations can only affect the fork choice of subsequent slots.
+    # Delay consideration in the fork choice until their slot is in the past.
+    assert get_current_slot(store) >= attestation.data.slot + 1
+ */
+func (s *Store) validateOnAttestation(attestation *core.Attestation) error {
+	data := attestation.Data
+	target := data.Target
+
+	// Attestations must be from the current or previous epoch
+	currentEpoch := shared.ComputeEpochAtSlot(s.currentSlot())
+	previousEpoch := params.ChainConfig.GenesisEpoch
+	if currentEpoch > params.ChainConfig.GenesisEpoch {
+		previousEpoch = currentEpoch - 1
+	}
+	if target.Epoch != currentEpoch && target.Epoch != previousEpoch {
+		return fmt.Errorf("target epoch %d not current nor previous", target.Epoch)
+	}
+	if target.Epoch != shared.ComputeEpochAtSlot(data.Slot) {
+		return fmt.Errorf("target epoch doesn't match attestation slot")
+	}
+
+	// Attestations target be for a known block
+	if _, ok := s.blocks[bytesutil.ToBytes32(target.Root)]; !ok {
+		return fmt.Errorf("target block %x not found", target.Root)
+	}
+
+	// Attestations must be for a known block
+	block, ok := s.blocks[bytesutil.ToBytes32(data.BeaconBlockRoot)]
+	if !ok {
+		return fmt.Errorf("beacon block %x not found", data.BeaconBlockRoot)
+	}
+	// Attestations must not be for blocks in the future
+	if block.Slot > data.Slot {
+		return fmt.Errorf("attestation is for a future block")
+	}
+
+	// LMD vote must be consistent with FFG vote target
+	targetSlot := shared.ComputeStartSlotAtEpoch(target.Epoch)
+	ancestor, err := s.getAncestor(bytesutil.ToBytes32(data.BeaconBlockRoot), targetSlot)
+	if err != nil {
+		return err
+	}
+	if ancestor != bytesutil.ToBytes32(target.Root) {
+		return fmt.Errorf("LMD vote is not consistent with FFG target")
+	}
+
+	// Attestations can only affect the fork choice of subsequent slots
+	if s.currentSlot() < data.Slot+1 {
+		return fmt.Errorf("attestation slot %d is not in the past", data.Slot)
+	}
+	return nil
+}
+
+/**
+def store_target_checkpoint_state(store: Store, target: Checkpoint) -> None:
+    # Store target checkpoint state if not yet seen
+    if target not in store.checkpoint_states:
+        base_state = store.block_states[target.root].copy()
+        process_slots(base_state, compute_start_slot_at_epoch(target.epoch))
+        store.checkpoint_states[target] = base_state
+ */
+func (s *Store) storeTargetCheckpointState(target *core.Checkpoint) (*core.State, error) {
+	key := checkpointKey(target)
+	if state, ok := s.checkpointStates[key]; ok {
+		return state, nil
+	}
+
+	baseState, ok := s.blockStates[bytesutil.ToBytes32(target.Root)]
+	if !ok {
+		return nil, fmt.Errorf("target block state %x not found", target.Root)
+	}
+	baseState = shared.CopyState(baseState)
+	if err := s.st.ProcessSlots(baseState, shared.ComputeStartSlotAtEpoch(target.Epoch)); err != nil {
+		return nil, err
+	}
+	s.checkpointStates[key] = baseState
+	return baseState, nil
+}
+
+/**
+def update_latest_messages(store: Store, attesting_indices: Sequence[ValidatorIndex], attestation: Attestation) -> None:
+    target = attestation.data.target
+    beacon_block_root = attestation.data.beacon_block_root
+    non_equivocating_attesting_indices = [i for i in attesting_indices if i not in store.equivocating_indices]
+    for i in non_equivocating_attesting_indices:
+        if i not in store.latest_messages or target.epoch > store.latest_messages[i].epoch:
+            store.latest_messages[i] = LatestMessage(epoch=target.epoch, root=beacon_block_root)
+ */
+func (s *Store) updateLatestMessages(attestingIndices []uint64, attestation *core.Attestation) {
+	target := attestation.Data.Target
+	beaconBlockRoot := bytesutil.ToBytes32(attestation.Data.BeaconBlockRoot)
+	for _, index := range attestingIndices {
+		if s.equivocatingIndices[index] {
+			continue
+		}
+		if msg, ok := s.latestMessages[index]; !ok || target.Epoch > msg.Epoch {
+			s.latestMessages[index] = &LatestMessage{Epoch: target.Epoch, Root: beaconBlockRoot}
+			s.backend.processAttestation(index, beaconBlockRoot, target.Epoch)
+		}
+	}
+}
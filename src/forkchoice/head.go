@@ -0,0 +1,262 @@
+package forkchoice
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// GetHead takes the write lock as the proto-array backend applies pending votes when computing the head
+func (s *Store) GetHead() ([]byte, error) {
+	defer s.publishEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	head, err := s.backend.findHead(s)
+	if err != nil {
+		return nil, fmt.Errorf("get head: %s", err.Error())
+	}
+	s.updateHead(head)
+	return head[:], nil
+}
+
+// specBackend runs get_head as specified, walking the filtered block tree on every call
+type specBackend struct{}
+
+func (b *specBackend) processBlock(root [32]byte, block *core.BlockHeader, justifiedEpoch uint64, finalizedEpoch uint64) error {
+	return nil
+}
+
+func (b *specBackend) processAttestation(index uint64, root [32]byte, targetEpoch uint64) {}
+
+func (b *specBackend) findHead(s *Store) ([32]byte, error) {
+	return s.getHead()
+}
+
+func (b *specBackend) weights(s *Store) (map[[32]byte]uint64, error) {
+	ret := make(map[[32]byte]uint64, len(s.blocks))
+	for root := range s.blocks {
+		weight, err := s.getLatestAttestingBalance(root)
+		if err != nil {
+			return nil, err
+		}
+		ret[root] = weight
+	}
+	return ret, nil
+}
+
+// the spec store keeps every block
+func (b *specBackend) prune(finalizedRoot [32]byte) ([][32]byte, error) {
+	return nil, nil
+}
+
+func (s *Store) getHead() ([32]byte, error) {
+	// Get filtered block tree that only includes viable branches
+	blocks := s.getFilteredBlockTree()
+
+	// Execute the LMD-GHOST fork choice
+	head := bytesutil.ToBytes32(s.justifiedCheckpoint.Root)
+	justifiedSlot := shared.ComputeStartSlotAtEpoch(s.justifiedCheckpoint.Epoch)
+	for {
+		var best [32]byte
+		bestBalance := uint64(0)
+		found := false
+		for root, block := range blocks {
+			if bytesutil.ToBytes32(block.ParentRoot) != head || block.Slot <= justifiedSlot {
+				continue
+			}
+			balance, err := s.getLatestAttestingBalance(root)
+			if err != nil {
+				return [32]byte{}, err
+			}
+			// Sort by latest attesting balance with ties broken lexicographically
+			if !found || balance > bestBalance || (balance == bestBalance && bytes.Compare(root[:], best[:]) > 0) {
+				best = root
+				bestBalance = balance
+				found = true
+			}
+		}
+		if !found {
+			return head, nil
+		}
+		head = best
+	}
+}
+
+/**
+def get_latest_attesting_balance(store: Store, root: Root) -> Gwei:
+    state = store.checkpoint_states[store.justified_checkpoint]
+    active_indices = get_active_validator_indices(state, get_current_epoch(state))
+    attestation_score = Gwei(sum(
+        state.validators[i].effective_balance for i in active_indices
+        if (i in store.latest_messages
+            and i not in store.equivocating_indices
+            and get_ancestor(store, store.latest_messages[i].root, store.blocks[root].slot) == root)
+    ))
+    if store.proposer_boost_root == Root():
+        # Return only attestation score if ``proposer_boost_root`` is not set
+        return attestation_score
+
+    # Calculate proposer score if ``proposer_boost_root`` is set
+    proposer_score = Gwei(0)
+    # Boost is applied if ``root`` is an ancestor of ``proposer_boost_root``
+    if get_ancestor(store, store.proposer_boost_root, store.blocks[root].slot) == root:
+        committee_weight = get_total_active_balance(state) // SLOTS_PER_EPOCH
+        proposer_score = (committee_weight * PROPOSER_SCORE_BOOST) // 100
+    return attestation_score + proposer_score
+ */
+func (s *Store) getLatestAttestingBalance(root [32]byte) (uint64, error) {
+	state, err := s.justifiedCheckpointState()
+	if err != nil {
+		return 0, err
+	}
+	block, ok := s.blocks[root]
+	if !ok {
+		return 0, fmt.Errorf("block %x not found", root)
+	}
+
+	sum := uint64(0)
+	for _, index := range shared.GetActiveValidators(state, shared.GetCurrentEpoch(state)) {
+		msg, ok := s.latestMessages[index]
+		if !ok || s.equivocatingIndices[index] {
+			continue
+		}
+		ancestor, err := s.getAncestor(msg.Root, block.Slot)
+		if err != nil {
+			return 0, err
+		}
+		if ancestor == root {
+			sum += state.Validators[index].EffectiveBalance
+		}
+	}
+	if s.proposerBoostRoot == [32]byte{} {
+		return sum, nil
+	}
+
+	// Boost is applied if root is an ancestor of proposer_boost_root
+	ancestor, err := s.getAncestor(s.proposerBoostRoot, block.Slot)
+	if err != nil {
+		return 0, err
+	}
+	if ancestor == root {
+		sum += proposerScore(state)
+	}
+	return sum, nil
+}
+
+// the justified checkpoint can move without an attestation targeting it (justified by the blocks themselves or
+// through best_justified on tick), its state is computed the first time it's needed
+func (s *Store) justifiedCheckpointState() (*core.State, error) {
+	state, err := s.storeTargetCheckpointState(s.justifiedCheckpoint)
+	if err != nil {
+		return nil, fmt.Errorf("justified checkpoint state: %s", err.Error())
+	}
+	return state, nil
+}
+
+func proposerScore(state *core.State) uint64 {
+	committeeWeight := shared.GetTotalActiveBalance(state) / params.ChainConfig.SlotsInEpoch
+	return committeeWeight * params.ChainConfig.ProposerScoreBoost / 100
+}
+
+/**
+def get_filtered_block_tree(store: Store) -> Dict[Root, BeaconBlock]:
+    """
+    Retrieve a filtered block tree from ``store``, only returning branches
+    whose leaf state's justified/finalized info agrees with that in ``store``.
+    """
+    base = store.justified_checkpoint.root
+    blocks: Dict[Root, BeaconBlock] = {}
+    filter_block_tree(store, base, blocks)
+    return blocks
+ */
+func (s *Store) getFilteredBlockTree() map[[32]byte]*core.BlockHeader {
+	children := make(map[[32]byte][][32]byte)
+	for root, block := range s.blocks {
+		parent := bytesutil.ToBytes32(block.ParentRoot)
+		children[parent] = append(children[parent], root)
+	}
+
+	ret := make(map[[32]byte]*core.BlockHeader)
+	s.filterBlockTree(bytesutil.ToBytes32(s.justifiedCheckpoint.Root), children, ret)
+	return ret
+}
+
+/**
+def filter_block_tree(store: Store, block_root: Root, blocks: Dict[Root, BeaconBlock]) -> bool:
+    block = store.blocks[block_root]
+    children = [
+        root for root in store.blocks.keys()
+        if store.blocks[root].parent_root == block_root
+    ]
+
+    # If any children branches contain expected finalized/justified checkpoints,
+    # add to filtered block-tree and signal viability to parent.
+    if any(children):
+        filter_block_tree_result = [filter_block_tree(store, child, blocks) for child in children]
+        if any(filter_block_tree_result):
+            blocks[block_root] = block
+            return True
+        return False
+
+    # If leaf block, check finalized/justified checkpoints as matching latest.
+    head_state = store.block_states[block_root]
+
+    correct_justified = (
+        store.justified_checkpoint.epoch == GENESIS_EPOCH
+        or head_state.current_justified_checkpoint == store.justified_checkpoint
+    )
+    correct_finalized = (
+        store.finalized_checkpoint.epoch == GENESIS_EPOCH
+        or head_state.finalized_checkpoint == store.finalized_checkpoint
+    )
+    # If expected finalized/justified, add to viable block-tree and signal viability to parent.
+    if correct_justified and correct_finalized:
+        blocks[block_root] = block
+        return True
+
+    # Otherwise, branch not viable
+    return False
+ */
+func (s *Store) filterBlockTree(blockRoot [32]byte, children map[[32]byte][][32]byte, blocks map[[32]byte]*core.BlockHeader) bool {
+	block, ok := s.blocks[blockRoot]
+	if !ok {
+		return false
+	}
+
+	// If any children branches contain expected finalized/justified checkpoints,
+	// add to filtered block-tree and signal viability to parent.
+	if len(children[blockRoot]) > 0 {
+		viable := false
+		for _, child := range children[blockRoot] {
+			if s.filterBlockTree(child, children, blocks) {
+				viable = true
+			}
+		}
+		if viable {
+			blocks[blockRoot] = block
+		}
+		return viable
+	}
+
+	// If leaf block, check finalized/justified checkpoints as matching latest.
+	headState, ok := s.blockStates[blockRoot]
+	if !ok {
+		return false
+	}
+	correctJustified := s.justifiedCheckpoint.Epoch == params.ChainConfig.GenesisEpoch ||
+		core.CheckpointsEqual(headState.CurrentJustifiedCheckpoint, s.justifiedCheckpoint)
+	correctFinalized := s.finalizedCheckpoint.Epoch == params.ChainConfig.GenesisEpoch ||
+		core.CheckpointsEqual(headState.FinalizedCheckpoint, s.finalizedCheckpoint)
+
+	// If expected finalized/justified, add to viable block-tree and signal viability to parent.
+	if correctJustified && correctFinalized {
+		blocks[blockRoot] = block
+		return true
+	}
+	// Otherwise, branch not viable
+	return false
+}
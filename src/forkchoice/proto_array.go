@@ -0,0 +1,328 @@
+package forkchoice
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// nodes are only removed from the array once at least this many are before the finalized node,
+// pruning shifts every index so it is amortized over many finalized epochs.
+const defaultPruneThreshold = 256
+
+const noneIndex = -1
+
+type protoNode struct {
+	slot           uint64
+	root           [32]byte
+	parent         int
+	justifiedEpoch uint64
+	finalizedEpoch uint64
+	// weight of the node and all of its descendants
+	weight         uint64
+	bestChild      int
+	bestDescendant int
+}
+
+type voteTracker struct {
+	currentRoot [32]byte
+	nextRoot    [32]byte
+	nextEpoch   uint64
+}
+
+// protoArray keeps the block tree as a flat array where every node comes after its parent.
+// Vote changes are applied as balance deltas walking the array backwards once, which propagates
+// weights to parents and updates each node's best child and best descendant so the head is a
+// single lookup from the justified node.
+type protoArray struct {
+	pruneThreshold int
+	justifiedEpoch uint64
+	finalizedEpoch uint64
+	nodes          []*protoNode
+	indices        map[[32]byte]int
+
+	votes []voteTracker
+	// effective balances used in the last head computation
+	balances []uint64
+	// proposer boost currently applied to the node weights
+	proposerBoostRoot  [32]byte
+	proposerBoostScore uint64
+}
+
+func newProtoArray(justifiedEpoch uint64, finalizedEpoch uint64) *protoArray {
+	return &protoArray{
+		pruneThreshold: defaultPruneThreshold,
+		justifiedEpoch: justifiedEpoch,
+		finalizedEpoch: finalizedEpoch,
+		nodes:          []*protoNode{},
+		indices:        map[[32]byte]int{},
+		votes:          []voteTracker{},
+		balances:       []uint64{},
+	}
+}
+
+func (p *protoArray) processBlock(root [32]byte, block *core.BlockHeader, justifiedEpoch uint64, finalizedEpoch uint64) error {
+	if _, ok := p.indices[root]; ok {
+		return nil
+	}
+
+	parent := noneIndex
+	if index, ok := p.indices[bytesutil.ToBytes32(block.ParentRoot)]; ok {
+		parent = index
+	}
+	index := len(p.nodes)
+	p.indices[root] = index
+	p.nodes = append(p.nodes, &protoNode{
+		slot:           block.Slot,
+		root:           root,
+		parent:         parent,
+		justifiedEpoch: justifiedEpoch,
+		finalizedEpoch: finalizedEpoch,
+		bestChild:      noneIndex,
+		bestDescendant: noneIndex,
+	})
+
+	if parent != noneIndex {
+		p.maybeUpdateBestChildAndDescendant(parent, index)
+	}
+	return nil
+}
+
+func (p *protoArray) processAttestation(index uint64, root [32]byte, targetEpoch uint64) {
+	for uint64(len(p.votes)) <= index {
+		p.votes = append(p.votes, voteTracker{})
+	}
+	p.votes[index].nextRoot = root
+	p.votes[index].nextEpoch = targetEpoch
+}
+
+func (p *protoArray) findHead(s *Store) ([32]byte, error) {
+	state, err := s.justifiedCheckpointState()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	newBalances := justifiedBalances(state)
+
+	deltas := computeDeltas(p.indices, p.votes, p.balances, newBalances, s.equivocatingIndices)
+	p.balances = newBalances
+
+	// the boost is only valid for a single slot, move it like a vote
+	if index, ok := p.indices[p.proposerBoostRoot]; ok {
+		deltas[index] -= int64(p.proposerBoostScore)
+	}
+	p.proposerBoostRoot = s.proposerBoostRoot
+	p.proposerBoostScore = 0
+	if index, ok := p.indices[p.proposerBoostRoot]; ok {
+		p.proposerBoostScore = proposerScore(state)
+		deltas[index] += int64(p.proposerBoostScore)
+	}
+	if err := p.applyScoreChanges(deltas, s.justifiedCheckpoint.Epoch, s.finalizedCheckpoint.Epoch); err != nil {
+		return [32]byte{}, err
+	}
+
+	justifiedRoot := bytesutil.ToBytes32(s.justifiedCheckpoint.Root)
+	justifiedIndex, ok := p.indices[justifiedRoot]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("justified block %x not found", justifiedRoot)
+	}
+	// the justified block is the head if none of its descendants is viable
+	bestDescendant := p.nodes[justifiedIndex].bestDescendant
+	if bestDescendant == noneIndex || !p.nodeIsViableForHead(p.nodes[bestDescendant]) {
+		return justifiedRoot, nil
+	}
+	return p.nodes[bestDescendant].root, nil
+}
+
+func (p *protoArray) weights(s *Store) (map[[32]byte]uint64, error) {
+	ret := make(map[[32]byte]uint64, len(p.nodes))
+	for _, node := range p.nodes {
+		ret[node.root] = node.weight
+	}
+	return ret, nil
+}
+
+// removes all nodes before the finalized node, returns the roots of the removed nodes
+func (p *protoArray) prune(finalizedRoot [32]byte) ([][32]byte, error) {
+	finalizedIndex, ok := p.indices[finalizedRoot]
+	if !ok {
+		return nil, fmt.Errorf("finalized block %x not found", finalizedRoot)
+	}
+	if finalizedIndex < p.pruneThreshold || finalizedIndex == 0 {
+		return nil, nil
+	}
+
+	pruned := make([][32]byte, 0, finalizedIndex)
+	for _, node := range p.nodes[:finalizedIndex] {
+		delete(p.indices, node.root)
+		pruned = append(pruned, node.root)
+	}
+	p.nodes = p.nodes[finalizedIndex:]
+	for root, index := range p.indices {
+		p.indices[root] = index - finalizedIndex
+	}
+
+	// children always come after their parent, only parents can point before the finalized node
+	for _, node := range p.nodes {
+		if node.parent < finalizedIndex {
+			node.parent = noneIndex
+		} else {
+			node.parent -= finalizedIndex
+		}
+		if node.bestChild != noneIndex {
+			node.bestChild -= finalizedIndex
+		}
+		if node.bestDescendant != noneIndex {
+			node.bestDescendant -= finalizedIndex
+		}
+	}
+	return pruned, nil
+}
+
+// applies the vote deltas to the node weights backwards so every delta is also added to the parent,
+// then updates best child and best descendant of every parent.
+func (p *protoArray) applyScoreChanges(deltas []int64, justifiedEpoch uint64, finalizedEpoch uint64) error {
+	if len(deltas) != len(p.nodes) {
+		return fmt.Errorf("deltas length %d doesn't match nodes length %d", len(deltas), len(p.nodes))
+	}
+	p.justifiedEpoch = justifiedEpoch
+	p.finalizedEpoch = finalizedEpoch
+
+	for i := len(p.nodes) - 1; i >= 0; i-- {
+		node := p.nodes[i]
+		delta := deltas[i]
+		if delta < 0 {
+			if uint64(-delta) > node.weight {
+				return fmt.Errorf("negative delta underflows node %x weight", node.root)
+			}
+			node.weight -= uint64(-delta)
+		} else {
+			node.weight += uint64(delta)
+		}
+		if node.parent != noneIndex {
+			deltas[node.parent] += delta
+		}
+	}
+
+	for i := len(p.nodes) - 1; i >= 0; i-- {
+		if parent := p.nodes[i].parent; parent != noneIndex {
+			p.maybeUpdateBestChildAndDescendant(parent, i)
+		}
+	}
+	return nil
+}
+
+func (p *protoArray) maybeUpdateBestChildAndDescendant(parentIndex int, childIndex int) {
+	parent := p.nodes[parentIndex]
+	child := p.nodes[childIndex]
+	childLeadsToViableHead := p.nodeLeadsToViableHead(child)
+
+	changeToNone := func() {
+		parent.bestChild = noneIndex
+		parent.bestDescendant = noneIndex
+	}
+	changeToChild := func() {
+		parent.bestChild = childIndex
+		if child.bestDescendant != noneIndex {
+			parent.bestDescendant = child.bestDescendant
+		} else {
+			parent.bestDescendant = childIndex
+		}
+	}
+
+	switch {
+	case parent.bestChild == childIndex:
+		// the best child may have become non viable or changed its best descendant
+		if childLeadsToViableHead {
+			changeToChild()
+		} else {
+			changeToNone()
+		}
+	case parent.bestChild == noneIndex:
+		if childLeadsToViableHead {
+			changeToChild()
+		}
+	default:
+		bestChild := p.nodes[parent.bestChild]
+		bestChildLeadsToViableHead := p.nodeLeadsToViableHead(bestChild)
+		if childLeadsToViableHead != bestChildLeadsToViableHead {
+			if childLeadsToViableHead {
+				changeToChild()
+			}
+			return
+		}
+		// ties are broken lexicographically
+		if child.weight > bestChild.weight ||
+			(child.weight == bestChild.weight && bytes.Compare(child.root[:], bestChild.root[:]) >= 0) {
+			changeToChild()
+		}
+	}
+}
+
+func (p *protoArray) nodeLeadsToViableHead(node *protoNode) bool {
+	if node.bestDescendant != noneIndex && p.nodeIsViableForHead(p.nodes[node.bestDescendant]) {
+		return true
+	}
+	return p.nodeIsViableForHead(node)
+}
+
+// same as the leaf check in filter_block_tree, by epochs only
+func (p *protoArray) nodeIsViableForHead(node *protoNode) bool {
+	correctJustified := p.justifiedEpoch == params.ChainConfig.GenesisEpoch || node.justifiedEpoch == p.justifiedEpoch
+	correctFinalized := p.finalizedEpoch == params.ChainConfig.GenesisEpoch || node.finalizedEpoch == p.finalizedEpoch
+	return correctJustified && correctFinalized
+}
+
+// returns the balance moved to and from every node since the last call, votes are updated to their
+// latest root. Votes for unknown (or pruned) blocks are ignored, equivocating validators have their
+// vote removed once and never counted again.
+func computeDeltas(indices map[[32]byte]int, votes []voteTracker, oldBalances []uint64, newBalances []uint64, equivocatingIndices map[uint64]bool) []int64 {
+	deltas := make([]int64, len(indices))
+	for validatorIndex := range votes {
+		vote := &votes[validatorIndex]
+
+		oldBalance := uint64(0)
+		if validatorIndex < len(oldBalances) {
+			oldBalance = oldBalances[validatorIndex]
+		}
+
+		if equivocatingIndices[uint64(validatorIndex)] {
+			if index, ok := indices[vote.currentRoot]; ok {
+				deltas[index] -= int64(oldBalance)
+			}
+			vote.currentRoot = [32]byte{}
+			vote.nextRoot = [32]byte{}
+			continue
+		}
+		newBalance := uint64(0)
+		if validatorIndex < len(newBalances) {
+			newBalance = newBalances[validatorIndex]
+		}
+
+		if vote.currentRoot == vote.nextRoot && oldBalance == newBalance {
+			continue
+		}
+		if index, ok := indices[vote.currentRoot]; ok {
+			deltas[index] -= int64(oldBalance)
+		}
+		if index, ok := indices[vote.nextRoot]; ok {
+			deltas[index] += int64(newBalance)
+		}
+		vote.currentRoot = vote.nextRoot
+	}
+	return deltas
+}
+
+// effective balances of the validators active in the justified state, zero for the rest
+func justifiedBalances(state *core.State) []uint64 {
+	ret := make([]uint64, len(state.Validators))
+	epoch := shared.GetCurrentEpoch(state)
+	for i, v := range state.Validators {
+		if shared.IsActiveValidator(v, epoch) {
+			ret[i] = v.EffectiveBalance
+		}
+	}
+	return ret
+}
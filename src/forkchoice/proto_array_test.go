@@ -0,0 +1,167 @@
+package forkchoice
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func root(b byte) [32]byte {
+	return [32]byte{b}
+}
+
+// builds 1 <- 2 <- 4 and 1 <- 3
+func testProtoArray(t *testing.T) *protoArray {
+	p := newProtoArray(0, 0)
+	blocks := []struct {
+		root   byte
+		parent byte
+		slot   uint64
+	}{
+		{1, 0, 0},
+		{2, 1, 1},
+		{3, 1, 2},
+		{4, 2, 3},
+	}
+	for _, b := range blocks {
+		parent := root(b.parent)
+		require.NoError(t, p.processBlock(root(b.root), &core.BlockHeader{Slot: b.slot, ParentRoot: parent[:]}, 0, 0))
+	}
+	return p
+}
+
+// applies pending votes the same way findHead does
+func applyVotes(t *testing.T, p *protoArray, balances []uint64, justifiedEpoch uint64) {
+	deltas := computeDeltas(p.indices, p.votes, p.balances, balances, map[uint64]bool{})
+	p.balances = balances
+	require.NoError(t, p.applyScoreChanges(deltas, justifiedEpoch, 0))
+}
+
+func bestDescendant(p *protoArray, r [32]byte) [32]byte {
+	node := p.nodes[p.indices[r]]
+	if node.bestDescendant == noneIndex {
+		return r
+	}
+	return p.nodes[node.bestDescendant].root
+}
+
+func TestProtoArrayVoteDeltas(t *testing.T) {
+	p := testProtoArray(t)
+	balances := []uint64{10, 10, 10}
+
+	// no votes, highest root wins on every level
+	applyVotes(t, p, balances, 0)
+	require.EqualValues(t, root(3), bestDescendant(p, root(1)))
+
+	// two votes for 3, one for 4
+	p.processAttestation(0, root(3), 0)
+	p.processAttestation(1, root(3), 0)
+	p.processAttestation(2, root(4), 0)
+	applyVotes(t, p, balances, 0)
+	require.EqualValues(t, 30, p.nodes[p.indices[root(1)]].weight)
+	require.EqualValues(t, 10, p.nodes[p.indices[root(2)]].weight)
+	require.EqualValues(t, 20, p.nodes[p.indices[root(3)]].weight)
+	require.EqualValues(t, root(3), bestDescendant(p, root(1)))
+
+	// a vote moves and validator 0 loses its balance, only the changes are applied
+	p.processAttestation(1, root(4), 1)
+	newBalances := []uint64{0, 10, 10}
+	applyVotes(t, p, newBalances, 0)
+	require.EqualValues(t, 20, p.nodes[p.indices[root(1)]].weight)
+	require.EqualValues(t, 20, p.nodes[p.indices[root(4)]].weight)
+	require.EqualValues(t, 0, p.nodes[p.indices[root(3)]].weight)
+	require.EqualValues(t, root(4), bestDescendant(p, root(1)))
+
+	// deltas must cover every node
+	require.Error(t, p.applyScoreChanges([]int64{1}, 0, 0))
+}
+
+func TestProtoArrayNonViableBranch(t *testing.T) {
+	p := newProtoArray(0, 0)
+	require.NoError(t, p.processBlock(root(1), &core.BlockHeader{ParentRoot: make([]byte, 32)}, 1, 0))
+	parent := root(1)
+	require.NoError(t, p.processBlock(root(2), &core.BlockHeader{Slot: 1, ParentRoot: parent[:]}, 1, 0))
+	require.NoError(t, p.processBlock(root(3), &core.BlockHeader{Slot: 1, ParentRoot: parent[:]}, 0, 0))
+
+	// a heavier branch which doesn't agree with the justified epoch is not followed
+	p.processAttestation(0, root(3), 1)
+	applyVotes(t, p, []uint64{10}, 1)
+	require.EqualValues(t, root(2), bestDescendant(p, root(1)))
+}
+
+func TestProtoArrayPrune(t *testing.T) {
+	p := testProtoArray(t)
+	p.processAttestation(0, root(4), 0)
+	applyVotes(t, p, []uint64{10}, 0)
+
+	// below the threshold nothing is pruned
+	pruned, err := p.prune(root(2))
+	require.NoError(t, err)
+	require.Len(t, pruned, 0)
+	require.Len(t, p.nodes, 4)
+
+	p.pruneThreshold = 0
+	pruned, err = p.prune(root(2))
+	require.NoError(t, err)
+	require.EqualValues(t, [][32]byte{root(1)}, pruned)
+	require.Len(t, p.nodes, 3)
+	require.Len(t, p.indices, 3)
+	require.EqualValues(t, noneIndex, p.nodes[p.indices[root(2)]].parent)
+	require.EqualValues(t, noneIndex, p.nodes[p.indices[root(3)]].parent)
+	require.EqualValues(t, root(4), bestDescendant(p, root(2)))
+
+	// pruned votes are dropped, remaining ones still apply
+	p.processAttestation(0, root(3), 1)
+	applyVotes(t, p, []uint64{10}, 0)
+	require.EqualValues(t, 0, p.nodes[p.indices[root(2)]].weight)
+	require.EqualValues(t, 10, p.nodes[p.indices[root(3)]].weight)
+
+	_, err = p.prune(root(1))
+	require.Error(t, err)
+}
+
+func TestProtoArrayStoreMatchesSpecStore(t *testing.T) {
+	state := genesisState()
+	specStore, err := NewStore(state)
+	require.NoError(t, err)
+	protoStore, err := NewProtoArrayStore(state)
+	require.NoError(t, err)
+	genesisRoot := specStore.JustifiedCheckpoint().Root
+
+	blockA, stateA := produceBlock(t, state, 1, 'a')
+	blockB, stateB := produceBlock(t, state, 2, 'b')
+	blockC, _ := produceBlock(t, stateB, 3, 'c')
+	time := state.GenesisTime + 4*params.ChainConfig.SecondsPerSlot
+
+	requireSameHead := func(expected []byte) {
+		specHead, err := specStore.GetHead()
+		require.NoError(t, err)
+		protoHead, err := protoStore.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, specHead, protoHead)
+		if expected != nil {
+			require.EqualValues(t, expected, protoHead)
+		}
+	}
+
+	for _, store := range []*Store{specStore, protoStore} {
+		store.OnTick(time)
+		require.NoError(t, store.OnBlock(blockA))
+		require.NoError(t, store.OnBlock(blockB))
+	}
+	requireSameHead(nil)
+
+	for _, store := range []*Store{specStore, protoStore} {
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateA, 1, blockRoot(t, blockA), genesisRoot)))
+	}
+	requireSameHead(blockRoot(t, blockA))
+
+	// two committees on the other fork outweigh the first
+	for _, store := range []*Store{specStore, protoStore} {
+		require.NoError(t, store.OnBlock(blockC))
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateB, 2, blockRoot(t, blockB), genesisRoot)))
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateB, 3, blockRoot(t, blockC), genesisRoot)))
+	}
+	requireSameHead(blockRoot(t, blockC))
+}
@@ -0,0 +1,403 @@
+package forkchoice
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"sync"
+)
+
+type IForkChoice interface {
+	// OnTick updates the store time, resets the proposer boost on a new slot and promotes the best justified
+	// checkpoint at epoch boundaries
+	//
+	// Spec pseudocode definition:
+	//  def on_tick(store: Store, time: uint64) -> None:
+	//    previous_slot = get_current_slot(store)
+	//
+	//    # update store time
+	//    store.time = time
+	//
+	//    current_slot = get_current_slot(store)
+	//
+	//    # Reset store.proposer_boost_root if this is a new slot
+	//    if current_slot > previous_slot:
+	//        store.proposer_boost_root = Root()
+	//
+	//    # Not a new epoch, return
+	//    if not (current_slot > previous_slot and compute_slots_since_epoch_start(current_slot) == 0):
+	//        return
+	//    # Update store.justified_checkpoint if a better checkpoint is known
+	//    if store.best_justified_checkpoint.epoch > store.justified_checkpoint.epoch:
+	//        store.justified_checkpoint = store.best_justified_checkpoint
+	OnTick(time uint64)
+
+	// OnBlock runs the full state transition for the block and adds it to the block tree
+	//
+	// Spec pseudocode definition:
+	//  def on_block(store: Store, signed_block: SignedBeaconBlock) -> None:
+	//    block = signed_block.message
+	//    # Parent block must be known
+	//    assert block.parent_root in store.block_states
+	//    # Make a copy of the state to avoid mutability issues
+	//    pre_state = store.block_states[block.parent_root].copy()
+	//    # Blocks cannot be in the future. If they are, their consideration must be delayed until the are in the past.
+	//    assert get_current_slot(store) >= block.slot
+	//
+	//    # Check that block is later than the finalized epoch slot (optimization to reduce calls to get_ancestor)
+	//    finalized_slot = compute_start_slot_at_epoch(store.finalized_checkpoint.epoch)
+	//    assert block.slot > finalized_slot
+	//    # Check block is a descendant of the finalized block at the checkpoint finalized slot
+	//    assert get_ancestor(store, block.parent_root, finalized_slot) == store.finalized_checkpoint.root
+	//
+	//    # Check the block is valid and compute the post-state
+	//    state = state_transition(pre_state, signed_block, True)
+	//    # Add new block to the store
+	//    store.blocks[hash_tree_root(block)] = block
+	//    # Add new state for this block to the store
+	//    store.block_states[hash_tree_root(block)] = state
+	//
+	//    # Add proposer score boost if the block is timely
+	//    time_into_slot = (store.time - store.genesis_time) % SECONDS_PER_SLOT
+	//    is_before_attesting_interval = time_into_slot < SECONDS_PER_SLOT // INTERVALS_PER_SLOT
+	//    if get_current_slot(store) == block.slot and is_before_attesting_interval:
+	//        store.proposer_boost_root = hash_tree_root(block)
+	//
+	//    # Update justified checkpoint
+	//    if state.current_justified_checkpoint.epoch > store.justified_checkpoint.epoch:
+	//        if state.current_justified_checkpoint.epoch > store.best_justified_checkpoint.epoch:
+	//            store.best_justified_checkpoint = state.current_justified_checkpoint
+	//        if should_update_justified_checkpoint(store, state.current_justified_checkpoint):
+	//            store.justified_checkpoint = state.current_justified_checkpoint
+	//
+	//    # Update finalized checkpoint
+	//    if state.finalized_checkpoint.epoch > store.finalized_checkpoint.epoch:
+	//        store.finalized_checkpoint = state.finalized_checkpoint
+	//
+	//        # Potentially update justified if different from store
+	//        if store.justified_checkpoint != state.current_justified_checkpoint:
+	//            # Update justified if new justified is later than store justified
+	//            if state.current_justified_checkpoint.epoch > store.justified_checkpoint.epoch:
+	//                store.justified_checkpoint = state.current_justified_checkpoint
+	//                return
+	//
+	//            # Update justified if store justified is not in chain with finalized checkpoint
+	//            finalized_slot = compute_start_slot_at_epoch(store.finalized_checkpoint.epoch)
+	//            ancestor_at_finalized_slot = get_ancestor(store, store.justified_checkpoint.root, finalized_slot)
+	//            if ancestor_at_finalized_slot != store.finalized_checkpoint.root:
+	//                store.justified_checkpoint = state.current_justified_checkpoint
+	OnBlock(signedBlock *core.SignedBlock) error
+
+	// OnAttestation validates the attestation against its target checkpoint state and records latest messages
+	//
+	// Spec pseudocode definition:
+	//  def on_attestation(store: Store, attestation: Attestation) -> None:
+	//    validate_on_attestation(store, attestation)
+	//    store_target_checkpoint_state(store, attestation.data.target)
+	//
+	//    # Get state at the `target` to fully validate attestation
+	//    target_state = store.checkpoint_states[attestation.data.target]
+	//    indexed_attestation = get_indexed_attestation(target_state, attestation)
+	//    assert is_valid_indexed_attestation(target_state, indexed_attestation)
+	//
+	//    # Update latest messages for attesting indices
+	//    update_latest_messages(store, indexed_attestation.attesting_indices, attestation)
+	OnAttestation(attestation *core.Attestation) error
+
+	// OnAttesterSlashing marks the validators slashed by the attester slashing as equivocating,
+	// their latest messages are not counted by the fork choice anymore.
+	//
+	// Spec pseudocode definition:
+	//  def on_attester_slashing(store: Store, attester_slashing: AttesterSlashing) -> None:
+	//    """
+	//    Run ``on_attester_slashing`` immediately upon receiving a new ``AttesterSlashing``
+	//    from either within a block or directly on the wire.
+	//    """
+	//    attestation_1 = attester_slashing.attestation_1
+	//    attestation_2 = attester_slashing.attestation_2
+	//    assert is_slashable_attestation_data(attestation_1.data, attestation_2.data)
+	//    state = store.block_states[store.justified_checkpoint.root]
+	//    assert is_valid_indexed_attestation(state, attestation_1)
+	//    assert is_valid_indexed_attestation(state, attestation_2)
+	//
+	//    indices = set(attestation_1.attesting_indices).intersection(attestation_2.attesting_indices)
+	//    for index in indices:
+	//        store.equivocating_indices.add(index)
+	OnAttesterSlashing(slashing *core.AttesterSlashing) error
+
+	// GetHead returns the root of the canonical head block by running LMD-GHOST from the justified checkpoint
+	//
+	// Spec pseudocode definition:
+	//  def get_head(store: Store) -> Root:
+	//    # Get filtered block tree that only includes viable branches
+	//    blocks = get_filtered_block_tree(store)
+	//    # Execute the LMD-GHOST fork choice
+	//    head = store.justified_checkpoint.root
+	//    justified_slot = compute_start_slot_at_epoch(store.justified_checkpoint.epoch)
+	//    while True:
+	//        children = [
+	//            root for root in blocks.keys()
+	//            if blocks[root].parent_root == head and blocks[root].slot > justified_slot
+	//        ]
+	//        if len(children) == 0:
+	//            return head
+	//        # Sort by latest attesting balance with ties broken lexicographically
+	//        head = max(children, key=lambda root: (get_latest_attesting_balance(store, root), root))
+	GetHead() ([]byte, error)
+}
+
+// backend selects the head among the blocks and latest messages known to the store
+type backend interface {
+	processBlock(root [32]byte, block *core.BlockHeader, justifiedEpoch uint64, finalizedEpoch uint64) error
+	processAttestation(index uint64, root [32]byte, targetEpoch uint64)
+	findHead(s *Store) ([32]byte, error)
+	// weights of the blocks as of the last findHead call
+	weights(s *Store) (map[[32]byte]uint64, error)
+	// returns the roots of the blocks dropped from the backend
+	prune(finalizedRoot [32]byte) ([][32]byte, error)
+}
+
+type LatestMessage struct {
+	Epoch uint64
+	Root  [32]byte
+}
+
+type Store struct {
+	lock    sync.RWMutex
+	st      *state_transition.StateTransition
+	backend backend
+
+	time                    uint64
+	genesisTime             uint64
+	justifiedCheckpoint     *core.Checkpoint
+	finalizedCheckpoint     *core.Checkpoint
+	bestJustifiedCheckpoint *core.Checkpoint
+	blocks                  map[[32]byte]*core.BlockHeader
+	blockStates             map[[32]byte]*core.State
+	checkpointStates        map[checkpointID]*core.State
+	latestMessages          map[uint64]*LatestMessage
+	proposerBoostRoot       [32]byte
+	equivocatingIndices     map[uint64]bool
+
+	// last computed head, events are queued under the lock and published once it's released
+	head             [32]byte
+	subscribers      []*subscriber
+	nextSubscriberID uint64
+	pendingEvents    []interface{}
+}
+
+/**
+def get_forkchoice_store(anchor_state: BeaconState) -> Store:
+    anchor_block_header = anchor_state.latest_block_header.copy()
+    if anchor_block_header.state_root == Bytes32():
+        anchor_block_header.state_root = hash_tree_root(anchor_state)
+    anchor_root = hash_tree_root(anchor_block_header)
+    anchor_epoch = get_current_epoch(anchor_state)
+    justified_checkpoint = Checkpoint(epoch=anchor_epoch, root=anchor_root)
+    finalized_checkpoint = Checkpoint(epoch=anchor_epoch, root=anchor_root)
+    return Store(
+        time=anchor_state.genesis_time + SECONDS_PER_SLOT * anchor_state.slot,
+        genesis_time=anchor_state.genesis_time,
+        justified_checkpoint=justified_checkpoint,
+        finalized_checkpoint=finalized_checkpoint,
+        best_justified_checkpoint=justified_checkpoint,
+        blocks={anchor_root: anchor_block_header},
+        block_states={anchor_root: anchor_state.copy()},
+        checkpoint_states={justified_checkpoint: anchor_state.copy()},
+    )
+ */
+func NewStore(anchorState *core.State) (*Store, error) {
+	return newStore(anchorState, &specBackend{})
+}
+
+// NewProtoArrayStore returns a store computing the head with a proto-array, applying vote changes
+// incrementally instead of walking the whole block tree. Blocks before the finalized checkpoint are pruned.
+func NewProtoArrayStore(anchorState *core.State) (*Store, error) {
+	anchorEpoch := shared.GetCurrentEpoch(anchorState)
+	return newStore(anchorState, newProtoArray(anchorEpoch, anchorEpoch))
+}
+
+func newStore(anchorState *core.State, backend backend) (*Store, error) {
+	anchorHeader := &core.BlockHeader{
+		Slot:          anchorState.LatestBlockHeader.Slot,
+		ProposerIndex: anchorState.LatestBlockHeader.ProposerIndex,
+		ParentRoot:    anchorState.LatestBlockHeader.ParentRoot,
+		StateRoot:     anchorState.LatestBlockHeader.StateRoot,
+		BodyRoot:      anchorState.LatestBlockHeader.BodyRoot,
+	}
+	if anchorHeader.StateRoot == nil || bytes.Equal(anchorHeader.StateRoot, params.ChainConfig.ZeroHash) {
+		stateRoot, err := anchorState.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("new store: %s", err.Error())
+		}
+		anchorHeader.StateRoot = stateRoot[:]
+	}
+	anchorRoot, err := anchorHeader.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("new store: %s", err.Error())
+	}
+	anchorEpoch := shared.GetCurrentEpoch(anchorState)
+
+	justified := &core.Checkpoint{Epoch: anchorEpoch, Root: anchorRoot[:]}
+	ret := &Store{
+		st:                      state_transition.NewStateTransition(),
+		backend:                 backend,
+		time:                    anchorState.GenesisTime + params.ChainConfig.SecondsPerSlot*anchorState.Slot,
+		genesisTime:             anchorState.GenesisTime,
+		justifiedCheckpoint:     justified,
+		finalizedCheckpoint:     copyCheckpoint(justified),
+		bestJustifiedCheckpoint: copyCheckpoint(justified),
+		blocks:                  map[[32]byte]*core.BlockHeader{anchorRoot: anchorHeader},
+		blockStates:             map[[32]byte]*core.State{anchorRoot: shared.CopyState(anchorState)},
+		checkpointStates:        map[checkpointID]*core.State{checkpointKey(justified): shared.CopyState(anchorState)},
+		latestMessages:          map[uint64]*LatestMessage{},
+		equivocatingIndices:     map[uint64]bool{},
+		head:                    anchorRoot,
+	}
+	// the anchor is viable by definition
+	if err := backend.processBlock(anchorRoot, anchorHeader, anchorEpoch, anchorEpoch); err != nil {
+		return nil, fmt.Errorf("new store: %s", err.Error())
+	}
+	return ret, nil
+}
+
+func (s *Store) Time() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.time
+}
+
+func (s *Store) GenesisTime() uint64 {
+	return s.genesisTime
+}
+
+func (s *Store) CurrentSlot() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.currentSlot()
+}
+
+func (s *Store) JustifiedCheckpoint() *core.Checkpoint {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return copyCheckpoint(s.justifiedCheckpoint)
+}
+
+func (s *Store) BestJustifiedCheckpoint() *core.Checkpoint {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return copyCheckpoint(s.bestJustifiedCheckpoint)
+}
+
+func (s *Store) FinalizedCheckpoint() *core.Checkpoint {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return copyCheckpoint(s.finalizedCheckpoint)
+}
+
+// returns nil if the block is unknown
+func (s *Store) Block(root []byte) *core.BlockHeader {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.blocks[bytesutil.ToBytes32(root)]
+}
+
+// returns nil if the block is unknown, the returned state is a copy
+func (s *Store) BlockState(root []byte) *core.State {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return shared.CopyState(s.blockStates[bytesutil.ToBytes32(root)])
+}
+
+// returns nil if the validator has no latest message
+func (s *Store) LatestMessage(index uint64) *LatestMessage {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if msg, ok := s.latestMessages[index]; ok {
+		return &LatestMessage{Epoch: msg.Epoch, Root: msg.Root}
+	}
+	return nil
+}
+
+// returns nil if no block is boosted in the current slot
+func (s *Store) ProposerBoostRoot() []byte {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.proposerBoostRoot == [32]byte{} {
+		return nil
+	}
+	ret := s.proposerBoostRoot
+	return ret[:]
+}
+
+func (s *Store) IsEquivocating(index uint64) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.equivocatingIndices[index]
+}
+
+/**
+def get_slots_since_genesis(store: Store) -> int:
+    return (store.time - store.genesis_time) // SECONDS_PER_SLOT
+
+def get_current_slot(store: Store) -> Slot:
+    return Slot(GENESIS_SLOT + get_slots_since_genesis(store))
+ */
+func (s *Store) currentSlot() uint64 {
+	genesisSlot := shared.ComputeStartSlotAtEpoch(params.ChainConfig.GenesisEpoch)
+	return genesisSlot + (s.time-s.genesisTime)/params.ChainConfig.SecondsPerSlot
+}
+
+/**
+def compute_slots_since_epoch_start(slot: Slot) -> int:
+    return slot - compute_start_slot_at_epoch(compute_epoch_at_slot(slot))
+ */
+func computeSlotsSinceEpochStart(slot uint64) uint64 {
+	return slot - shared.ComputeStartSlotAtEpoch(shared.ComputeEpochAtSlot(slot))
+}
+
+/**
+def get_ancestor(store: Store, root: Root, slot: Slot) -> Root:
+    block = store.blocks[root]
+    if block.slot > slot:
+        return get_ancestor(store, block.parent_root, slot)
+    elif block.slot == slot:
+        return root
+    else:
+        # root is older than queried slot, thus a skip slot. Return most recent root prior to slot
+        return root
+ */
+func (s *Store) getAncestor(root [32]byte, slot uint64) ([32]byte, error) {
+	for {
+		block, ok := s.blocks[root]
+		if !ok {
+			return [32]byte{}, fmt.Errorf("get ancestor: block %x not found", root)
+		}
+		if block.Slot <= slot {
+			return root, nil
+		}
+		root = bytesutil.ToBytes32(block.ParentRoot)
+	}
+}
+
+type checkpointID struct {
+	epoch uint64
+	root  [32]byte
+}
+
+func checkpointKey(checkpoint *core.Checkpoint) checkpointID {
+	return checkpointID{epoch: checkpoint.Epoch, root: bytesutil.ToBytes32(checkpoint.Root)}
+}
+
+func copyCheckpoint(checkpoint *core.Checkpoint) *core.Checkpoint {
+	if checkpoint == nil {
+		return nil
+	}
+	root := make([]byte, len(checkpoint.Root))
+	copy(root, checkpoint.Root)
+	return &core.Checkpoint{Epoch: checkpoint.Epoch, Root: root}
+}
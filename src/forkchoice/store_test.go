@@ -0,0 +1,335 @@
+package forkchoice
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func genesisState() *core.State {
+	return state_transition.NewStateTestContext(params.ChainConfig, nil, 0).
+		PopulateGenesisValidator(params.ChainConfig.MinGenesisActiveValidatorCount).
+		State
+}
+
+func secretKey(t *testing.T, index uint64) *bls.SecretKey {
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(hex.EncodeToString([]byte(fmt.Sprintf("%d", index)))))
+	return sk
+}
+
+// produces a valid signed block at slot on top of parentState, graffiti is used to fork siblings
+func produceBlock(t *testing.T, parentState *core.State, slot uint64, graffiti byte, attestations ...*core.Attestation) (*core.SignedBlock, *core.State) {
+	st := state_transition.NewStateTransition()
+
+	state := shared.CopyState(parentState)
+	require.NoError(t, st.ProcessSlots(state, slot))
+	proposer, err := shared.GetBlockProposerIndex(state)
+	require.NoError(t, err)
+	sk := secretKey(t, proposer)
+
+	data, domain, err := state_transition.RANDAOSigningData(state)
+	require.NoError(t, err)
+	randaoRoot, err := shared.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	parentRoot, err := state.LatestBlockHeader.HashTreeRoot()
+	require.NoError(t, err)
+
+	graffitiByts := make([]byte, 32)
+	graffitiByts[0] = graffiti
+	block := &core.Block{
+		Slot:       slot,
+		Proposer:   proposer,
+		ParentRoot: parentRoot[:],
+		StateRoot:  params.ChainConfig.ZeroHash,
+		Body: &core.BlockBody{
+			RandaoReveal: sk.SignByte(randaoRoot[:]).Serialize(),
+			Eth1Data:     state.Eth1Data,
+			Graffiti:     graffitiByts,
+			Attestations: attestations,
+		},
+	}
+	postState, err := st.ExecuteStateTransition(parentState, &core.SignedBlock{Block: block, Signature: make([]byte, 96)}, false)
+	require.NoError(t, err)
+	postRoot, err := postState.HashTreeRoot()
+	require.NoError(t, err)
+	block.StateRoot = postRoot[:]
+
+	blockDomain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconProposer, shared.GetCurrentEpoch(state))
+	require.NoError(t, err)
+	sig, err := shared.SignBlock(block, []byte(fmt.Sprintf("%d", proposer)), blockDomain)
+	require.NoError(t, err)
+	return &core.SignedBlock{Block: block, Signature: sig.Serialize()}, postState
+}
+
+// produces an attestation signed by the whole committee voting for headRoot
+func produceAttestation(t *testing.T, state *core.State, slot uint64, headRoot []byte, targetRoot []byte) *core.Attestation {
+	epoch := shared.ComputeEpochAtSlot(slot)
+	data := &core.AttestationData{
+		Slot:            slot,
+		CommitteeIndex:  0,
+		BeaconBlockRoot: headRoot,
+		Source:          state.CurrentJustifiedCheckpoint,
+		Target:          &core.Checkpoint{Epoch: epoch, Root: targetRoot},
+	}
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconAttester, epoch)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	committee, err := shared.GetBeaconCommittee(state, slot, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	var sig *bls.Sign
+	for i, index := range committee {
+		bits.SetBitAt(uint64(i), true)
+		if sig == nil {
+			sig = secretKey(t, index).SignByte(root[:])
+		} else {
+			sig.Add(secretKey(t, index).SignByte(root[:]))
+		}
+	}
+	return &core.Attestation{AggregationBits: bits, Data: data, Signature: sig.Serialize()}
+}
+
+func blockRoot(t *testing.T, block *core.SignedBlock) []byte {
+	root, err := block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	return root[:]
+}
+
+func TestGenesisHead(t *testing.T) {
+	state := genesisState()
+	store, err := NewStore(state)
+	require.NoError(t, err)
+
+	head, err := store.GetHead()
+	require.NoError(t, err)
+	require.EqualValues(t, store.JustifiedCheckpoint().Root, head)
+	require.EqualValues(t, store.FinalizedCheckpoint().Root, head)
+	require.NotNil(t, store.Block(head))
+}
+
+func TestOnBlockChain(t *testing.T) {
+	state := genesisState()
+	store, err := NewStore(state)
+	require.NoError(t, err)
+
+	block1, state1 := produceBlock(t, state, 1, 0)
+	block2, _ := produceBlock(t, state1, 2, 0)
+
+	// blocks from the future are rejected
+	require.EqualError(t, store.OnBlock(block1), "on block: block slot 1 is in the future")
+
+	store.OnTick(state.GenesisTime + 2*params.ChainConfig.SecondsPerSlot)
+	// parent unknown
+	require.Error(t, store.OnBlock(block2))
+
+	require.NoError(t, store.OnBlock(block1))
+	require.NoError(t, store.OnBlock(block2))
+
+	head, err := store.GetHead()
+	require.NoError(t, err)
+	require.EqualValues(t, blockRoot(t, block2), head)
+	require.EqualValues(t, blockRoot(t, block1), store.Block(head).ParentRoot)
+}
+
+func TestJustificationFromBlocks(t *testing.T) {
+	state := genesisState()
+	anchor, err := NewStore(state)
+	require.NoError(t, err)
+
+	// every slot's committee votes for its block, the vote is included in the next block
+	targets := map[uint64][]byte{0: anchor.JustifiedCheckpoint().Root}
+	var blocks []*core.SignedBlock
+	var pending *core.Attestation
+	postState := state
+	for slot := uint64(1); slot <= 3*params.ChainConfig.SlotsInEpoch; slot++ {
+		var attestations []*core.Attestation
+		if pending != nil {
+			attestations = append(attestations, pending)
+		}
+		block, post := produceBlock(t, postState, slot, 0, attestations...)
+		epoch := shared.ComputeEpochAtSlot(slot)
+		if _, ok := targets[epoch]; !ok {
+			targets[epoch] = blockRoot(t, block)
+		}
+		pending = produceAttestation(t, post, slot, blockRoot(t, block), targets[epoch])
+		blocks = append(blocks, block)
+		postState = post
+	}
+	require.NotZero(t, postState.CurrentJustifiedCheckpoint.Epoch)
+
+	for _, newStore := range []func(*core.State) (*Store, error){NewStore, NewProtoArrayStore} {
+		store, err := newStore(state)
+		require.NoError(t, err)
+
+		store.OnTick(state.GenesisTime + 3*params.ChainConfig.SlotsInEpoch*params.ChainConfig.SecondsPerSlot)
+		for _, block := range blocks {
+			require.NoError(t, store.OnBlock(block))
+		}
+		require.EqualValues(t, postState.CurrentJustifiedCheckpoint, store.JustifiedCheckpoint())
+
+		// no attestation went through on_attestation, the justified checkpoint state comes from the blocks
+		head, err := store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, blocks[len(blocks)-1]), head)
+	}
+}
+
+func TestForkHeadFollowsVotes(t *testing.T) {
+	state := genesisState()
+	store, err := NewStore(state)
+	require.NoError(t, err)
+	genesisRoot := store.JustifiedCheckpoint().Root
+
+	blockA, stateA := produceBlock(t, state, 1, 'a')
+	blockB, _ := produceBlock(t, state, 2, 'b')
+
+	store.OnTick(state.GenesisTime + 3*params.ChainConfig.SecondsPerSlot)
+	require.NoError(t, store.OnBlock(blockA))
+	require.NoError(t, store.OnBlock(blockB))
+
+	// no votes, ties are broken lexicographically
+	head, err := store.GetHead()
+	require.NoError(t, err)
+	expected := blockRoot(t, blockA)
+	if string(blockRoot(t, blockB)) > string(expected) {
+		expected = blockRoot(t, blockB)
+	}
+	require.EqualValues(t, expected, head)
+
+	// a committee voting for A at slot 1 decides the fork
+	att := produceAttestation(t, stateA, 1, blockRoot(t, blockA), genesisRoot)
+	require.NoError(t, store.OnAttestation(att))
+	head, err = store.GetHead()
+	require.NoError(t, err)
+	require.EqualValues(t, blockRoot(t, blockA), head)
+
+	committee, err := shared.GetBeaconCommittee(stateA, 1, 0)
+	require.NoError(t, err)
+	require.NotNil(t, store.LatestMessage(committee[0]))
+	require.EqualValues(t, blockRoot(t, blockA), store.LatestMessage(committee[0]).Root[:])
+}
+
+func TestOnAttestationValidation(t *testing.T) {
+	state := genesisState()
+	store, err := NewStore(state)
+	require.NoError(t, err)
+	genesisRoot := store.JustifiedCheckpoint().Root
+
+	block1, state1 := produceBlock(t, state, 1, 0)
+	store.OnTick(state.GenesisTime + params.ChainConfig.SecondsPerSlot)
+	require.NoError(t, store.OnBlock(block1))
+
+	// attestation slot is not in the past yet
+	att := produceAttestation(t, state1, 1, blockRoot(t, block1), genesisRoot)
+	require.EqualError(t, store.OnAttestation(att), "on attestation: attestation slot 1 is not in the past")
+
+	// unknown block
+	store.OnTick(state.GenesisTime + 2*params.ChainConfig.SecondsPerSlot)
+	unknown := produceAttestation(t, state1, 1, make([]byte, 32), genesisRoot)
+	require.Error(t, store.OnAttestation(unknown))
+
+	// target inconsistent with the LMD vote
+	wrongTarget := produceAttestation(t, state1, 1, blockRoot(t, block1), blockRoot(t, block1))
+	require.EqualError(t, store.OnAttestation(wrongTarget), "on attestation: LMD vote is not consistent with FFG target")
+
+	// bad signature
+	att.Signature = wrongTarget.Signature
+	require.Error(t, store.OnAttestation(att))
+}
+
+// a double vote by the committee of slot
+func produceAttesterSlashing(t *testing.T, state *core.State, slot uint64, targetRoot []byte) *core.AttesterSlashing {
+	otherRoot := make([]byte, 32)
+	otherRoot[0] = 0xff
+	indexed1, err := shared.GetIndexedAttestation(state, produceAttestation(t, state, slot, targetRoot, targetRoot))
+	require.NoError(t, err)
+	indexed2, err := shared.GetIndexedAttestation(state, produceAttestation(t, state, slot, otherRoot, targetRoot))
+	require.NoError(t, err)
+	return &core.AttesterSlashing{Attestation_1: indexed1, Attestation_2: indexed2}
+}
+
+func TestProposerBoost(t *testing.T) {
+	state := genesisState()
+	block1, _ := produceBlock(t, state, 2, 1)
+	block2, _ := produceBlock(t, state, 2, 2)
+	timely, late := block1, block2
+	if string(blockRoot(t, timely)) > string(blockRoot(t, late)) {
+		timely, late = late, timely
+	}
+
+	for _, newStore := range []func(*core.State) (*Store, error){NewStore, NewProtoArrayStore} {
+		store, err := newStore(state)
+		require.NoError(t, err)
+
+		store.OnTick(state.GenesisTime + 2*params.ChainConfig.SecondsPerSlot)
+		require.NoError(t, store.OnBlock(timely))
+		require.EqualValues(t, blockRoot(t, timely), store.ProposerBoostRoot())
+
+		// past the attesting interval the block is not boosted
+		store.OnTick(state.GenesisTime + 2*params.ChainConfig.SecondsPerSlot + params.ChainConfig.SecondsPerSlot/params.ChainConfig.IntervalsPerSlot)
+		require.NoError(t, store.OnBlock(late))
+		require.EqualValues(t, blockRoot(t, timely), store.ProposerBoostRoot())
+
+		// the boost outweighs the lexicographic tie break
+		head, err := store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, timely), head)
+
+		// and is reset on the next slot
+		store.OnTick(state.GenesisTime + 3*params.ChainConfig.SecondsPerSlot)
+		require.Nil(t, store.ProposerBoostRoot())
+		head, err = store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, late), head)
+	}
+}
+
+func TestEquivocatingVotesDiscounted(t *testing.T) {
+	state := genesisState()
+	blockA, stateA := produceBlock(t, state, 1, 'a')
+	blockB, stateB := produceBlock(t, state, 2, 'b')
+
+	for _, newStore := range []func(*core.State) (*Store, error){NewStore, NewProtoArrayStore} {
+		store, err := newStore(state)
+		require.NoError(t, err)
+		genesisRoot := store.JustifiedCheckpoint().Root
+
+		store.OnTick(state.GenesisTime + 4*params.ChainConfig.SecondsPerSlot)
+		require.NoError(t, store.OnBlock(blockA))
+		require.NoError(t, store.OnBlock(blockB))
+
+		// two committees vote for A, one for B
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateA, 1, blockRoot(t, blockA), genesisRoot)))
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateA, 3, blockRoot(t, blockA), genesisRoot)))
+		require.NoError(t, store.OnAttestation(produceAttestation(t, stateB, 2, blockRoot(t, blockB), genesisRoot)))
+		head, err := store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, blockA), head)
+
+		// both committees voting for A get slashed
+		slashing := produceAttesterSlashing(t, state, 1, genesisRoot)
+		require.NoError(t, store.OnAttesterSlashing(slashing))
+		require.NoError(t, store.OnAttesterSlashing(produceAttesterSlashing(t, state, 3, genesisRoot)))
+		for _, index := range slashing.Attestation_1.AttestingIndices {
+			require.True(t, store.IsEquivocating(index))
+		}
+		head, err = store.GetHead()
+		require.NoError(t, err)
+		require.EqualValues(t, blockRoot(t, blockB), head)
+
+		// same attestation twice is not slashable
+		slashing.Attestation_2 = slashing.Attestation_1
+		require.EqualError(t, store.OnAttesterSlashing(slashing), "on attester slashing: attestation data not slashable")
+	}
+}
@@ -0,0 +1,136 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/ghodss/yaml"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+const (
+	// SSZFileName is the name of the ssz encoded genesis state written by WriteGenesis
+	SSZFileName = "genesis.ssz"
+	// YAMLFileName is the name of the human readable genesis summary written by WriteGenesis
+	YAMLFileName = "genesis.yaml"
+)
+
+// Summary describes a genesis state, StateRoot is the root nodes should verify the genesis state against.
+type Summary struct {
+	GenesisTime           uint64 `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+	ValidatorCount        uint64 `json:"validator_count"`
+	StateRoot             string `json:"state_root"`
+}
+
+// NewSummary returns the summary of the genesis state
+func NewSummary(state *core.State) (*Summary, error) {
+	if state.Fork == nil {
+		return nil, fmt.Errorf("genesis summary: state has no fork")
+	}
+	root, err := state.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("genesis summary: %s", err.Error())
+	}
+	return &Summary{
+		GenesisTime:           state.GenesisTime,
+		GenesisValidatorsRoot: encodeHex(state.GenesisValidatorsRoot),
+		GenesisForkVersion:    encodeHex(state.Fork.CurrentVersion),
+		ValidatorCount:        uint64(len(state.Validators)),
+		StateRoot:             encodeHex(root[:]),
+	}, nil
+}
+
+// Root returns the decoded state root of the summary
+func (s *Summary) Root() ([32]byte, error) {
+	return DecodeRoot(s.StateRoot)
+}
+
+// WriteGenesis writes the ssz encoded state and its yaml summary into dir
+func WriteGenesis(dir string, state *core.State) (*Summary, error) {
+	summary, err := NewSummary(state)
+	if err != nil {
+		return nil, err
+	}
+	byts, err := state.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("write genesis: %s", err.Error())
+	}
+	yamlByts, err := yaml.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("write genesis: %s", err.Error())
+	}
+
+	if err := ioutil.WriteFile(path.Join(dir, SSZFileName), byts, 0644); err != nil {
+		return nil, fmt.Errorf("write genesis: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(path.Join(dir, YAMLFileName), yamlByts, 0644); err != nil {
+		return nil, fmt.Errorf("write genesis: %s", err.Error())
+	}
+	return summary, nil
+}
+
+// LoadGenesis reads an ssz encoded genesis state and verifies its hash tree root against expectedRoot,
+// every node starting from the same file and root starts from exactly the same state.
+func LoadGenesis(file string, expectedRoot [32]byte) (*core.State, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("load genesis: %s", err.Error())
+	}
+	state, err := DecodeGenesis(byts, expectedRoot)
+	if err != nil {
+		return nil, fmt.Errorf("load genesis %s: %s", file, err.Error())
+	}
+	return state, nil
+}
+
+// DecodeGenesis decodes an ssz encoded genesis state and verifies its hash tree root against expectedRoot
+func DecodeGenesis(byts []byte, expectedRoot [32]byte) (*core.State, error) {
+	state := &core.State{}
+	if err := state.UnmarshalSSZ(byts); err != nil {
+		return nil, fmt.Errorf("invalid ssz: %s", err.Error())
+	}
+	root, err := state.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(root[:], expectedRoot[:]) {
+		return nil, fmt.Errorf("state root %s does not match expected root %s", encodeHex(root[:]), encodeHex(expectedRoot[:]))
+	}
+	return state, nil
+}
+
+// LoadSummary reads a yaml genesis summary
+func LoadSummary(file string) (*Summary, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("load genesis summary: %s", err.Error())
+	}
+	ret := &Summary{}
+	if err := yaml.Unmarshal(byts, ret); err != nil {
+		return nil, fmt.Errorf("load genesis summary: %s", err.Error())
+	}
+	return ret, nil
+}
+
+// DecodeRoot decodes a 0x prefixed 32 byte root
+func DecodeRoot(str string) ([32]byte, error) {
+	byts, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid root %s: %s", str, err.Error())
+	}
+	if len(byts) != 32 {
+		return [32]byte{}, fmt.Errorf("invalid root %s: must be 32 bytes", str)
+	}
+	ret := [32]byte{}
+	copy(ret[:], byts)
+	return ret, nil
+}
+
+func encodeHex(byts []byte) string {
+	return "0x" + hex.EncodeToString(byts)
+}
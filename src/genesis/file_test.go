@@ -0,0 +1,64 @@
+package genesis
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestWriteAndLoadGenesis(t *testing.T) {
+	state, _, err := InteropGenesisState(1606824000, 8)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "genesis")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	summary, err := WriteGenesis(dir, state)
+	require.NoError(t, err)
+	require.EqualValues(t, 1606824000, summary.GenesisTime)
+	require.EqualValues(t, 8, summary.ValidatorCount)
+	require.EqualValues(t, encodeHex(state.GenesisValidatorsRoot), summary.GenesisValidatorsRoot)
+	require.EqualValues(t, "0x00000000", summary.GenesisForkVersion)
+
+	loadedSummary, err := LoadSummary(path.Join(dir, YAMLFileName))
+	require.NoError(t, err)
+	require.EqualValues(t, summary, loadedSummary)
+
+	root, err := loadedSummary.Root()
+	require.NoError(t, err)
+	expectedRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	require.EqualValues(t, expectedRoot, root)
+
+	loaded, err := LoadGenesis(path.Join(dir, SSZFileName), root)
+	require.NoError(t, err)
+	require.EqualValues(t, state.GenesisValidatorsRoot, loaded.GenesisValidatorsRoot)
+	require.Len(t, loaded.Validators, 8)
+
+	// a different state
+	root[0] ^= 1
+	_, err = LoadGenesis(path.Join(dir, SSZFileName), root)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "does not match expected root"))
+
+	// a corrupted file
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, SSZFileName), []byte{1, 2, 3}, 0644))
+	_, err = LoadGenesis(path.Join(dir, SSZFileName), expectedRoot)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "invalid ssz"))
+}
+
+func TestDecodeRoot(t *testing.T) {
+	root, err := DecodeRoot("0x" + strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	require.EqualValues(t, 0xab, root[31])
+
+	_, err = DecodeRoot("0xabcd")
+	require.EqualError(t, err, "invalid root 0xabcd: must be 32 bytes")
+	_, err = DecodeRoot("0xzz")
+	require.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package genesis
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+/**
+def initialize_beacon_state_from_eth1(eth1_block_hash: Bytes32,
+                                      eth1_timestamp: uint64,
+                                      deposits: Sequence[Deposit]) -> BeaconState:
+    fork = Fork(
+        previous_version=GENESIS_FORK_VERSION,
+        current_version=GENESIS_FORK_VERSION,
+        epoch=GENESIS_EPOCH,
+    )
+    state = BeaconState(
+        genesis_time=eth1_timestamp + GENESIS_DELAY,
+        fork=fork,
+        eth1_data=Eth1Data(block_hash=eth1_block_hash, deposit_count=len(deposits)),
+        latest_block_header=BeaconBlockHeader(body_root=hash_tree_root(BeaconBlockBody())),
+        randao_mixes=[eth1_block_hash] * EPOCHS_PER_HISTORICAL_VECTOR,  # Seed RANDAO with Eth1 entropy
+    )
+
+    # Process deposits
+    leaves = list(map(lambda deposit: deposit.data, deposits))
+    for index, deposit in enumerate(deposits):
+        deposit_data_list = List[DepositData, 2**DEPOSIT_CONTRACT_TREE_DEPTH](*leaves[:index + 1])
+        state.eth1_data.deposit_root = hash_tree_root(deposit_data_list)
+        process_deposit(state, deposit)
+
+    # Process activations
+    for index, validator in enumerate(state.validators):
+        balance = state.balances[index]
+        validator.effective_balance = min(balance - balance % EFFECTIVE_BALANCE_INCREMENT, MAX_EFFECTIVE_BALANCE)
+        if validator.effective_balance == MAX_EFFECTIVE_BALANCE:
+            validator.activation_eligibility_epoch = GENESIS_EPOCH
+            validator.activation_epoch = GENESIS_EPOCH
+
+    # Set genesis validators root for domain separation and chain versioning
+    state.genesis_validators_root = hash_tree_root(state.validators)
+
+    return state
+ */
+// InitializeBeaconStateFromEth1 builds the genesis state from the deposits, each deposit's proof is against the
+// deposit root of the deposits up to and including it. Returns an error if the state is not a valid genesis state.
+func InitializeBeaconStateFromEth1(eth1BlockHash []byte, eth1Timestamp uint64, deposits []*core.Deposit) (*core.State, error) {
+	state, err := initializeBeaconStateFromEth1(eth1BlockHash, eth1Timestamp, deposits)
+	if err != nil {
+		return nil, err
+	}
+	if !shared.IsValidGenesisState(state) {
+		return nil, fmt.Errorf("genesis: state is not a valid genesis state")
+	}
+	return state, nil
+}
+
+func initializeBeaconStateFromEth1(eth1BlockHash []byte, eth1Timestamp uint64, deposits []*core.Deposit) (*core.State, error) {
+	if len(eth1BlockHash) != 32 {
+		return nil, fmt.Errorf("genesis: eth1 block hash must be 32 bytes")
+	}
+
+	state, err := emptyGenesisState(eth1BlockHash, eth1Timestamp, uint64(len(deposits)))
+	if err != nil {
+		return nil, fmt.Errorf("genesis: %s", err.Error())
+	}
+
+	// Process deposits, the deposit root grows with every deposit
+	trie, err := trieutil.NewTrie(int(params.ChainConfig.DepositContractTreeDepth))
+	if err != nil {
+		return nil, fmt.Errorf("genesis: %s", err.Error())
+	}
+	for index, deposit := range deposits {
+		if deposit == nil || deposit.Data == nil {
+			return nil, fmt.Errorf("genesis: deposit %d is nil", index)
+		}
+		leaf, err := deposit.Data.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("genesis: %s", err.Error())
+		}
+		trie.Insert(leaf[:], index)
+		depositRoot := trie.Root()
+		state.Eth1Data.DepositRoot = depositRoot[:]
+
+		if err := state_transition.ProcessDeposit(state, deposit); err != nil {
+			return nil, fmt.Errorf("genesis: deposit %d: %s", index, err.Error())
+		}
+	}
+
+	// Process activations
+	for index, validator := range state.Validators {
+		balance := state.Balances[index]
+		validator.EffectiveBalance = mathutil.Min(balance-balance%params.ChainConfig.EffectiveBalanceIncrement, params.ChainConfig.MaxEffectiveBalance)
+		if validator.EffectiveBalance == params.ChainConfig.MaxEffectiveBalance {
+			validator.ActivationEligibilityEpoch = params.ChainConfig.GenesisEpoch
+			validator.ActivationEpoch = params.ChainConfig.GenesisEpoch
+		}
+	}
+
+	// Set genesis validators root for domain separation and chain versioning
+	validatorsRoot, err := ValidatorsRoot(state.Validators)
+	if err != nil {
+		return nil, fmt.Errorf("genesis: %s", err.Error())
+	}
+	state.GenesisValidatorsRoot = validatorsRoot[:]
+	return state, nil
+}
+
+// ValidatorsRoot returns hash_tree_root(List[Validator, VALIDATOR_REGISTRY_LIMIT])
+func ValidatorsRoot(validators []*core.Validator) ([32]byte, error) {
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, validator := range validators {
+		if err := validator.HashTreeRootWith(hh); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(validators)), params.ChainConfig.ValidatorRegistryLimit)
+	return hh.HashRoot()
+}
+
+func emptyGenesisState(eth1BlockHash []byte, eth1Timestamp uint64, depositCount uint64) (*core.State, error) {
+	zeroHash := make([]byte, 32)
+
+	// hash_tree_root(BeaconBlockBody())
+	bodyRoot, err := (&core.BlockBody{
+		RandaoReveal: make([]byte, 96),
+		Eth1Data: &core.ETH1Data{
+			DepositRoot: zeroHash,
+			BlockHash:   zeroHash,
+		},
+		Graffiti: zeroHash,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	// root of an empty deposit list
+	emptyTrie, err := trieutil.NewTrie(int(params.ChainConfig.DepositContractTreeDepth))
+	if err != nil {
+		return nil, err
+	}
+	depositRoot := emptyTrie.HashTreeRoot()
+
+	randaoMixes := make([][]byte, params.ChainConfig.EpochsPerHistoricalVector)
+	for i := range randaoMixes {
+		randaoMixes[i] = eth1BlockHash
+	}
+	blockRoots := make([][]byte, params.ChainConfig.SlotsPerHistoricalRoot)
+	for i := range blockRoots {
+		blockRoots[i] = zeroHash
+	}
+	stateRoots := make([][]byte, params.ChainConfig.SlotsPerHistoricalRoot)
+	for i := range stateRoots {
+		stateRoots[i] = zeroHash
+	}
+
+	return &core.State{
+		GenesisTime: eth1Timestamp + params.ChainConfig.GenesisDelay,
+		Slot:        0,
+		Fork: &core.Fork{
+			PreviousVersion: params.ChainConfig.GenesisForkVersion,
+			CurrentVersion:  params.ChainConfig.GenesisForkVersion,
+			Epoch:           params.ChainConfig.GenesisEpoch,
+		},
+		LatestBlockHeader: &core.BlockHeader{
+			ParentRoot: zeroHash,
+			StateRoot:  zeroHash,
+			BodyRoot:   bodyRoot[:],
+		},
+		BlockRoots:                  blockRoots,
+		StateRoots:                  stateRoots,
+		HistoricalRoots:             [][]byte{},
+		GenesisValidatorsRoot:       zeroHash,
+		Eth1Data:                    &core.ETH1Data{DepositRoot: depositRoot[:], DepositCount: depositCount, BlockHash: eth1BlockHash},
+		Eth1DataVotes:               []*core.ETH1Data{},
+		Eth1DepositIndex:            0,
+		Validators:                  []*core.Validator{},
+		Balances:                    []uint64{},
+		RandaoMixes:                 randaoMixes,
+		Slashings:                   make([]uint64, params.ChainConfig.EpochsPerSlashingVector),
+		PreviousEpochAttestations:   []*core.PendingAttestation{},
+		CurrentEpochAttestations:    []*core.PendingAttestation{},
+		JustificationBits:           []byte{0},
+		PreviousJustifiedCheckpoint: &core.Checkpoint{Epoch: 0, Root: zeroHash},
+		CurrentJustifiedCheckpoint:  &core.Checkpoint{Epoch: 0, Root: zeroHash},
+		FinalizedCheckpoint:         &core.Checkpoint{Epoch: 0, Root: zeroHash},
+	}, nil
+}
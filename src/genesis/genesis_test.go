@@ -0,0 +1,119 @@
+package genesis
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func depositData(t *testing.T, index uint64, amount uint64) *core.Deposit_DepositData {
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(hex.EncodeToString([]byte(fmt.Sprintf("%d", index)))))
+
+	msg := &core.DepositMessage{
+		PublicKey:             sk.GetPublicKey().Serialize(),
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                amount,
+	}
+	domain, err := shared.ComputeDomain(params.ChainConfig.DomainDeposit, nil, nil)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(msg, domain)
+	require.NoError(t, err)
+	return &core.Deposit_DepositData{
+		PublicKey:             msg.PublicKey,
+		WithdrawalCredentials: msg.WithdrawalCredentials,
+		Amount:                msg.Amount,
+		Signature:             sk.SignByte(root[:]).Serialize(),
+	}
+}
+
+func TestInitializeBeaconStateFromEth1(t *testing.T) {
+	count := params.ChainConfig.MinGenesisActiveValidatorCount
+	data := make([]*core.Deposit_DepositData, 0)
+	for i := uint64(0); i < count; i++ {
+		data = append(data, depositData(t, i, params.ChainConfig.MaxEffectiveBalance))
+	}
+	// a partial deposit is not activated, a top up is added to the existing validator
+	data = append(data, depositData(t, count, params.ChainConfig.MaxEffectiveBalance/2))
+	data = append(data, depositData(t, 0, params.ChainConfig.MaxEffectiveBalance))
+	deposits, err := DepositsWithProofs(data)
+	require.NoError(t, err)
+
+	eth1BlockHash := make([]byte, 32)
+	eth1BlockHash[0] = 0x42
+	state, err := InitializeBeaconStateFromEth1(eth1BlockHash, params.ChainConfig.MinGenesisTime, deposits)
+	require.NoError(t, err)
+
+	require.EqualValues(t, params.ChainConfig.MinGenesisTime+params.ChainConfig.GenesisDelay, state.GenesisTime)
+	require.EqualValues(t, eth1BlockHash, state.Eth1Data.BlockHash)
+	require.EqualValues(t, eth1BlockHash, state.RandaoMixes[0])
+	depositLeaves := make([][]byte, len(data))
+	for i, d := range data {
+		root, err := d.HashTreeRoot()
+		require.NoError(t, err)
+		depositLeaves[i] = root[:]
+	}
+	depositTrie, err := trieutil.GenerateTrieFromItems(depositLeaves, int(params.ChainConfig.DepositContractTreeDepth))
+	require.NoError(t, err)
+	depositRoot := depositTrie.Root()
+	require.EqualValues(t, depositRoot[:], state.Eth1Data.DepositRoot)
+	require.EqualValues(t, len(deposits), state.Eth1Data.DepositCount)
+	require.EqualValues(t, len(deposits), state.Eth1DepositIndex)
+
+	require.Len(t, state.Validators, int(count)+1)
+	require.Len(t, shared.GetActiveValidators(state, params.ChainConfig.GenesisEpoch), int(count))
+	require.EqualValues(t, 2*params.ChainConfig.MaxEffectiveBalance, state.Balances[0])
+	require.EqualValues(t, params.ChainConfig.MaxEffectiveBalance, state.Validators[0].EffectiveBalance)
+	require.EqualValues(t, params.ChainConfig.MaxEffectiveBalance/2, state.Validators[count].EffectiveBalance)
+	require.EqualValues(t, params.ChainConfig.FarFutureEpoch, state.Validators[count].ActivationEpoch)
+
+	// hash_tree_root(List[Validator, 2**40]) is a depth 40 tree with the length mixed in
+	leaves := make([][]byte, len(state.Validators))
+	for i, v := range state.Validators {
+		root, err := v.HashTreeRoot()
+		require.NoError(t, err)
+		leaves[i] = root[:]
+	}
+	validatorsTrie, err := trieutil.GenerateTrieFromItems(leaves, 40)
+	require.NoError(t, err)
+	validatorsRoot := validatorsTrie.Root()
+	require.EqualValues(t, validatorsRoot[:], state.GenesisValidatorsRoot)
+
+	// the state is well formed
+	_, err = state.HashTreeRoot()
+	require.NoError(t, err)
+}
+
+func TestInitializeBeaconStateFromEth1Invalid(t *testing.T) {
+	eth1BlockHash := make([]byte, 32)
+	data := make([]*core.Deposit_DepositData, 0)
+	for i := uint64(0); i < params.ChainConfig.MinGenesisActiveValidatorCount-1; i++ {
+		data = append(data, depositData(t, i, params.ChainConfig.MaxEffectiveBalance))
+	}
+	deposits, err := DepositsWithProofs(data)
+	require.NoError(t, err)
+
+	// not enough validators
+	_, err = InitializeBeaconStateFromEth1(eth1BlockHash, params.ChainConfig.MinGenesisTime, deposits)
+	require.EqualError(t, err, "genesis: state is not a valid genesis state")
+
+	// too early
+	deposits, err = DepositsWithProofs(append(data, depositData(t, uint64(len(data)), params.ChainConfig.MaxEffectiveBalance)))
+	require.NoError(t, err)
+	_, err = InitializeBeaconStateFromEth1(eth1BlockHash, params.ChainConfig.MinGenesisTime-params.ChainConfig.GenesisDelay-1, deposits)
+	require.EqualError(t, err, "genesis: state is not a valid genesis state")
+
+	// proofs must be against the growing deposit root
+	deposits[1].Proof = deposits[0].Proof
+	_, err = InitializeBeaconStateFromEth1(eth1BlockHash, params.ChainConfig.MinGenesisTime, deposits)
+	require.Error(t, err)
+
+	_, err = InitializeBeaconStateFromEth1(make([]byte, 4), params.ChainConfig.MinGenesisTime, deposits)
+	require.EqualError(t, err, "genesis: eth1 block hash must be 32 bytes")
+}
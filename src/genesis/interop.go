@@ -0,0 +1,128 @@
+package genesis
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/deposit"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keyderivation"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// Interop genesis as specified in https://github.com/ethereum/eth2.0-pm/tree/master/interop/mocked_start,
+// other eth2 clients produce the same keys and genesis state byte for byte.
+
+// eth1 timestamp used for interop genesis, the genesis time is overridden afterwards
+const interopEth1Timestamp = 1 << 40
+
+// 0x42 repeated 32 times
+var interopEth1BlockHash = []byte{
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+}
+
+// InteropSecretKey returns the secret key of the validator at index:
+//  int.from_bytes(sha256(index.to_bytes(32, 'little')), 'little') % CURVE_ORDER
+func InteropSecretKey(index uint64) (*bls.SecretKey, error) {
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint64(buf, index)
+	hash := sha256.Sum256(buf)
+
+	sk := &bls.SecretKey{}
+	if err := sk.SetLittleEndianMod(hash[:]); err != nil {
+		return nil, fmt.Errorf("interop key %d: %s", index, err.Error())
+	}
+	return sk, nil
+}
+
+// InteropSecretKeys returns the secret keys of validators 0 to count-1
+func InteropSecretKeys(count uint64) ([]*bls.SecretKey, error) {
+	ret := make([]*bls.SecretKey, count)
+	for i := uint64(0); i < count; i++ {
+		sk, err := InteropSecretKey(i)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = sk
+	}
+	return ret, nil
+}
+
+// InteropWithdrawalCredentials returns BLS_WITHDRAWAL_PREFIX + sha256(pubkey)[1:]
+func InteropWithdrawalCredentials(pubKey []byte) []byte {
+	return keyderivation.WithdrawalCredentials(pubKey)
+}
+
+// InteropDepositData returns a signed MAX_EFFECTIVE_BALANCE deposit for every key
+func InteropDepositData(keys []*bls.SecretKey) ([]*core.Deposit_DepositData, error) {
+	domain, err := shared.ComputeDomain(params.ChainConfig.DomainDeposit, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*core.Deposit_DepositData, len(keys))
+	for i, sk := range keys {
+		pubKey := sk.GetPublicKey().Serialize()
+		msg := &core.DepositMessage{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: InteropWithdrawalCredentials(pubKey),
+			Amount:                params.ChainConfig.MaxEffectiveBalance,
+		}
+		root, err := shared.ComputeSigningRoot(msg, domain)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = &core.Deposit_DepositData{
+			PublicKey:             msg.PublicKey,
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                msg.Amount,
+			Signature:             sk.SignByte(root[:]).Serialize(),
+		}
+	}
+	return ret, nil
+}
+
+// DepositsWithProofs wraps the deposit data with the proofs initialize_beacon_state_from_eth1 expects,
+// each proof is against the deposit root of the deposits up to and including it.
+func DepositsWithProofs(data []*core.Deposit_DepositData) ([]*core.Deposit, error) {
+	tree := deposit.NewTree()
+	ret := make([]*core.Deposit, len(data))
+	for i, d := range data {
+		if err := tree.Add(d); err != nil {
+			return nil, err
+		}
+		withProof, err := tree.Deposit(uint64(i), uint64(i)+1)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = withProof
+	}
+	return ret, nil
+}
+
+// InteropGenesisState returns the interop genesis state for validatorCount validators and their secret keys.
+// The state is not checked with is_valid_genesis_state so small devnets can start with any number of validators.
+func InteropGenesisState(genesisTime uint64, validatorCount uint64) (*core.State, []*bls.SecretKey, error) {
+	keys, err := InteropSecretKeys(validatorCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("interop genesis: %s", err.Error())
+	}
+	data, err := InteropDepositData(keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("interop genesis: %s", err.Error())
+	}
+	deposits, err := DepositsWithProofs(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("interop genesis: %s", err.Error())
+	}
+
+	state, err := initializeBeaconStateFromEth1(interopEth1BlockHash, interopEth1Timestamp, deposits)
+	if err != nil {
+		return nil, nil, err
+	}
+	state.GenesisTime = genesisTime
+	return state, keys, nil
+}
@@ -0,0 +1,78 @@
+package genesis
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// from eth2.0-pm/interop/mocked_start/keygen_10_validators.yaml
+var interopKeys = []struct {
+	sk string
+	pk string
+}{
+	{
+		sk: "25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+		pk: "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+	},
+	{
+		sk: "51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+		pk: "b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+	},
+	{
+		sk: "315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857",
+		pk: "a3a32b0f8b4ddb83f1a0a853d81dd725dfe577d4f4c3db8ece52ce2b026eca84815c1a7e8e92a4de3d755733bf7e4a9b",
+	},
+}
+
+func TestInteropSecretKeys(t *testing.T) {
+	keys, err := InteropSecretKeys(uint64(len(interopKeys)))
+	require.NoError(t, err)
+	for i, expected := range interopKeys {
+		require.EqualValues(t, expected.sk, hex.EncodeToString(keys[i].Serialize()))
+		require.EqualValues(t, expected.pk, hex.EncodeToString(keys[i].GetPublicKey().Serialize()))
+	}
+}
+
+func TestInteropGenesisState(t *testing.T) {
+	state, keys, err := InteropGenesisState(1606824023, 8)
+	require.NoError(t, err)
+	require.Len(t, keys, 8)
+	require.Len(t, state.Validators, 8)
+	require.EqualValues(t, 1606824023, state.GenesisTime)
+	require.EqualValues(t, interopEth1BlockHash, state.Eth1Data.BlockHash)
+	require.Len(t, shared.GetActiveValidators(state, params.ChainConfig.GenesisEpoch), 8)
+
+	for i, v := range state.Validators {
+		require.EqualValues(t, keys[i].GetPublicKey().Serialize(), v.PublicKey)
+		require.EqualValues(t, params.ChainConfig.BLSWithdrawalPrefix, v.WithdrawalCredentials[:1])
+		require.EqualValues(t, InteropWithdrawalCredentials(v.PublicKey), v.WithdrawalCredentials)
+	}
+
+	// reproducible
+	other, _, err := InteropGenesisState(1606824023, 8)
+	require.NoError(t, err)
+	root, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	otherRoot, err := other.HashTreeRoot()
+	require.NoError(t, err)
+	require.EqualValues(t, root, otherRoot)
+}
+
+// genesis validators roots of interop genesis as computed by other clients (prysm's shared/interop)
+func TestInteropGenesisValidatorsRoot(t *testing.T) {
+	tests := []struct {
+		validators uint64
+		root       string
+	}{
+		{validators: 16, root: "05d79a31a8e69ecd78114c50ce73116be33027d8431b82fff6c0b3cd9c2cbb1f"},
+		{validators: 64, root: "83431ec7fcf92cfc44947fc0418e831c25e1d0806590231c439830db7ad54fda"},
+	}
+	for _, test := range tests {
+		state, _, err := InteropGenesisState(1606824023, test.validators)
+		require.NoError(t, err)
+		require.EqualValues(t, test.root, hex.EncodeToString(state.GenesisValidatorsRoot))
+	}
+}
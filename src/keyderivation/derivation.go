@@ -0,0 +1,154 @@
+package keyderivation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/hkdf"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// EIP-2333 key tree, https://eips.ethereum.org/EIPS/eip-2333
+// Secret keys are 32 bytes big endian, the format the SDK's signing functions and keystores take.
+
+// the BLS12-381 curve order
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// SeedFromMnemonic returns the BIP-39 seed of mnemonic and passphrase, the mnemonic's checksum is verified
+func SeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("seed from mnemonic: %s", err.Error())
+	}
+	return seed, nil
+}
+
+/**
+def derive_master_SK(seed: bytes) -> int:
+    if len(seed) < 32:
+        raise ValueError("`len(seed)` should be greater than or equal to 32.")
+    return HKDF_mod_r(seed)
+ */
+func DeriveMasterSK(seed []byte) ([]byte, error) {
+	if len(seed) < 32 {
+		return nil, fmt.Errorf("derive master sk: seed must be at least 32 bytes")
+	}
+	return hkdfModR(seed)
+}
+
+/**
+def derive_child_SK(parent_SK: int, index: int) -> int:
+    compressed_lamport_PK = parent_SK_to_lamport_PK(parent_SK, index)
+    return HKDF_mod_r(compressed_lamport_PK)
+ */
+func DeriveChildSK(parentSK []byte, index uint32) ([]byte, error) {
+	if len(parentSK) != 32 {
+		return nil, fmt.Errorf("derive child sk: parent sk must be 32 bytes")
+	}
+	lamportPK, err := parentSKToLamportPK(parentSK, index)
+	if err != nil {
+		return nil, fmt.Errorf("derive child sk: %s", err.Error())
+	}
+	return hkdfModR(lamportPK)
+}
+
+// DerivePath derives the secret key at path (e.g. m/12381/3600/0/0/0) from seed
+func DerivePath(seed []byte, path string) ([]byte, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derive path: path must start with m")
+	}
+	sk, err := DeriveMasterSK(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts[1:] {
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("derive path: invalid index %s", part)
+		}
+		if sk, err = DeriveChildSK(sk, uint32(index)); err != nil {
+			return nil, err
+		}
+	}
+	return sk, nil
+}
+
+/**
+def IKM_to_lamport_SK(IKM: bytes, salt: bytes) -> List[bytes]:
+    OKM = HKDF(salt=salt, IKM=IKM, info=b'', L=8160)
+    lamport_SK = [OKM[i: i + 32] for i in range(0, 8160, 32)]
+    return lamport_SK
+
+def parent_SK_to_lamport_PK(parent_SK: int, index: int) -> bytes:
+    salt = index.to_bytes(4, byteorder='big')
+    IKM = parent_SK.to_bytes(32, byteorder='big')
+    lamport_0 = IKM_to_lamport_SK(IKM, salt)
+    not_IKM = bytes(e ^ 0xff for e in IKM)
+    lamport_1 = IKM_to_lamport_SK(not_IKM, salt)
+    lamport_SKs = lamport_0 + lamport_1
+    lamport_PKs = [SHA256(sk) for sk in lamport_SKs]
+    compressed_PK = SHA256(b''.join(lamport_PKs))
+    return compressed_PK
+ */
+func parentSKToLamportPK(parentSK []byte, index uint32) ([]byte, error) {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+	notIKM := make([]byte, len(parentSK))
+	for i, b := range parentSK {
+		notIKM[i] = b ^ 0xff
+	}
+
+	compressed := sha256.New()
+	for _, ikm := range [][]byte{parentSK, notIKM} {
+		okm := make([]byte, 8160)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm); err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(okm); i += 32 {
+			pk := sha256.Sum256(okm[i : i+32])
+			compressed.Write(pk[:])
+		}
+	}
+	return compressed.Sum(nil), nil
+}
+
+/**
+def HKDF_mod_r(IKM: bytes, key_info: bytes=b'') -> int:
+    L = 48  # `ceil((3 * ceil(log2(r))) / 16)`, where `r` is the order of the BLS 12-381 curve
+    salt = b'BLS-SIG-KEYGEN-SALT-'
+    SK = 0
+    while SK == 0:
+        salt = SHA256(salt)
+        okm = HKDF(
+            salt=salt,
+            IKM=IKM + b'\x00',  # add postfix `I2OSP(0, 1)`
+            L=L,
+            info=key_info + L.to_bytes(2, 'big'),
+        )
+        SK = int.from_bytes(okm, byteorder='big') % bls_curve_order
+    return SK
+ */
+func hkdfModR(ikm []byte) ([]byte, error) {
+	const l = 48
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	info := []byte{0, l}
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		hash := sha256.Sum256(salt)
+		salt = hash[:]
+		okm := make([]byte, l)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, append(append([]byte{}, ikm...), 0), salt, info), okm); err != nil {
+			return nil, err
+		}
+		sk.Mod(new(big.Int).SetBytes(okm), curveOrder)
+	}
+	ret := make([]byte, 32)
+	byts := sk.Bytes()
+	copy(ret[32-len(byts):], byts)
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+package keyderivation
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+// test vectors from https://eips.ethereum.org/EIPS/eip-2333
+func TestDeriveVectors(t *testing.T) {
+	tests := []struct {
+		seed     string
+		masterSK string
+		index    uint32
+		childSK  string
+	}{
+		{
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			masterSK: "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			index:    0,
+			childSK:  "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		},
+		{
+			seed:     "3141592653589793238462643383279502884197169399375105820974944592",
+			masterSK: "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			index:    3141592653,
+			childSK:  "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+	}
+
+	for _, test := range tests {
+		seed, err := hex.DecodeString(test.seed)
+		require.NoError(t, err)
+
+		master, err := DeriveMasterSK(seed)
+		require.NoError(t, err)
+		require.EqualValues(t, test.masterSK, new(big.Int).SetBytes(master).String())
+
+		child, err := DeriveChildSK(master, test.index)
+		require.NoError(t, err)
+		require.EqualValues(t, test.childSK, new(big.Int).SetBytes(child).String())
+	}
+
+	_, err := DeriveMasterSK(make([]byte, 31))
+	require.EqualError(t, err, "derive master sk: seed must be at least 32 bytes")
+}
+
+func TestSeedFromMnemonic(t *testing.T) {
+	// the seed of the first EIP-2333 test vector
+	seed, err := SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+	require.NoError(t, err)
+	require.EqualValues(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	_, err = SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	require.Error(t, err)
+}
+
+func TestDeriveValidatorKeys(t *testing.T) {
+	seed, err := SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+
+	keys, err := DeriveValidatorKeys(seed, 2, 3)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	for i, k := range keys {
+		require.EqualValues(t, 2+i, k.Index)
+		signingKey, err := DerivePath(seed, k.SigningPath)
+		require.NoError(t, err)
+		require.EqualValues(t, signingKey, k.SigningKey)
+		require.EqualValues(t, "m/12381/3600/"+string(rune('2'+i))+"/0/0", k.SigningPath)
+
+		withdrawalKey, err := DerivePath(seed, k.WithdrawalPath)
+		require.NoError(t, err)
+		require.EqualValues(t, withdrawalKey, k.WithdrawalKey)
+		credentials, err := k.WithdrawalCredentials()
+		require.NoError(t, err)
+		require.EqualValues(t, 0, credentials[0])
+		require.Len(t, credentials, 32)
+
+		// the keys sign with the SDK
+		pubKey, err := k.SigningPubKey()
+		require.NoError(t, err)
+		block := &core.Block{Slot: 1, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: &core.BlockBody{
+			RandaoReveal: make([]byte, 96),
+			Eth1Data:     &core.ETH1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+			Graffiti:     make([]byte, 32),
+		}}
+		sig, err := shared.SignBlock(block, k.SigningKey, make([]byte, 32))
+		require.NoError(t, err)
+		require.NoError(t, shared.VerifyBlockSigningRoot(block, pubKey, sig.Serialize(), make([]byte, 32)))
+	}
+
+	_, err = DerivePath(seed, "12381/3600/0/0")
+	require.EqualError(t, err, "derive path: path must start with m")
+	_, err = DerivePath(seed, "m/12381/a")
+	require.EqualError(t, err, "derive path: invalid index a")
+}
@@ -0,0 +1,91 @@
+package keyderivation
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// EIP-2334 validator key paths, https://eips.ethereum.org/EIPS/eip-2334
+
+func init() {
+	if err := shared.InitBLS(); err != nil {
+		panic(err)
+	}
+}
+
+// WithdrawalKeyPath returns the path of the index-th validator's withdrawal key
+func WithdrawalKeyPath(index uint64) string {
+	return fmt.Sprintf("m/12381/3600/%d/0", index)
+}
+
+// SigningKeyPath returns the path of the index-th validator's signing key
+func SigningKeyPath(index uint64) string {
+	return fmt.Sprintf("m/12381/3600/%d/0/0", index)
+}
+
+// ValidatorKeys are the signing and withdrawal keys of a validator derived from a seed
+type ValidatorKeys struct {
+	Index          uint64
+	SigningPath    string
+	SigningKey     []byte
+	WithdrawalPath string
+	WithdrawalKey  []byte
+}
+
+// DeriveValidatorKeys derives the keys of validators [from, from + count) from seed
+func DeriveValidatorKeys(seed []byte, from uint64, count uint64) ([]*ValidatorKeys, error) {
+	ret := make([]*ValidatorKeys, 0, count)
+	for index := from; index < from+count; index++ {
+		// the signing key is the withdrawal key's child
+		withdrawalKey, err := DerivePath(seed, WithdrawalKeyPath(index))
+		if err != nil {
+			return nil, err
+		}
+		signingKey, err := DeriveChildSK(withdrawalKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &ValidatorKeys{
+			Index:          index,
+			SigningPath:    SigningKeyPath(index),
+			SigningKey:     signingKey,
+			WithdrawalPath: WithdrawalKeyPath(index),
+			WithdrawalKey:  withdrawalKey,
+		})
+	}
+	return ret, nil
+}
+
+// SigningPubKey returns the validator's pubkey
+func (k *ValidatorKeys) SigningPubKey() ([]byte, error) {
+	return publicKey(k.SigningKey)
+}
+
+// WithdrawalCredentials returns the BLS withdrawal credentials of the validator's withdrawal key
+func (k *ValidatorKeys) WithdrawalCredentials() ([]byte, error) {
+	pubKey, err := publicKey(k.WithdrawalKey)
+	if err != nil {
+		return nil, err
+	}
+	return WithdrawalCredentials(pubKey), nil
+}
+
+// WithdrawalCredentials returns BLS_WITHDRAWAL_PREFIX + sha256(pubKey)[1:]
+func WithdrawalCredentials(pubKey []byte) []byte {
+	hash := sha256.Sum256(pubKey)
+	ret := make([]byte, 32)
+	copy(ret, hash[:])
+	copy(ret, params.ChainConfig.BLSWithdrawalPrefix)
+	return ret
+}
+
+func publicKey(sk []byte) ([]byte, error) {
+	privKey := &bls.SecretKey{}
+	if err := privKey.Deserialize(sk); err != nil {
+		return nil, err
+	}
+	return privKey.GetPublicKey().Serialize(), nil
+}
@@ -0,0 +1,331 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+	"io/ioutil"
+	"strings"
+)
+
+// EIP-2335 keystores, https://eips.ethereum.org/EIPS/eip-2335
+
+const (
+	Version = 4
+
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	checksumFunction = "sha256"
+	cipherFunction   = "aes-128-ctr"
+	prfFunction      = "hmac-sha256"
+)
+
+// default kdf costs, as recommended by the EIP
+var (
+	scryptN = 1 << 18
+	pbkdf2C = 1 << 18
+)
+
+// the kdf params come from the keystore file, decrypting refuses costs above these (4 times the defaults, 1GiB of
+// memory for scrypt) instead of deriving for ever
+const (
+	maxScryptN  = 1 << 20
+	maxScryptR  = 8
+	maxScryptP  = 16
+	maxPBKDF2C  = 1 << 20
+	maxKDFDKLen = 64
+)
+
+func init() {
+	if err := shared.InitBLS(); err != nil {
+		panic(err)
+	}
+}
+
+// Keystore is an encrypted BLS secret key
+type Keystore struct {
+	Crypto      *Crypto `json:"crypto"`
+	Description string  `json:"description"`
+	PubKey      string  `json:"pubkey"`
+	Path        string  `json:"path"`
+	UUID        string  `json:"uuid"`
+	Version     uint    `json:"version"`
+}
+
+type Crypto struct {
+	KDF      *Module `json:"kdf"`
+	Checksum *Module `json:"checksum"`
+	Cipher   *Module `json:"cipher"`
+}
+
+type Module struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// Encrypt returns a keystore of the secret key sk (32 bytes, big endian) encrypted with password. kdf is KDFScrypt or
+// KDFPBKDF2, path is the key's EIP-2334 derivation path (empty if not derived).
+func Encrypt(sk []byte, password string, path string, kdf string) (*Keystore, error) {
+	pubKey, err := publicKey(sk)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+	iv := make([]byte, 16)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+
+	kdfModule := &Module{Function: kdf}
+	switch kdf {
+	case KDFScrypt:
+		kdfModule.Params, err = json.Marshal(&scryptParams{DKLen: 32, N: scryptN, P: 1, R: 8, Salt: hex.EncodeToString(salt)})
+	case KDFPBKDF2:
+		kdfModule.Params, err = json.Marshal(&pbkdf2Params{DKLen: 32, C: pbkdf2C, PRF: prfFunction, Salt: hex.EncodeToString(salt)})
+	default:
+		return nil, fmt.Errorf("encrypt keystore: unsupported kdf %s", kdf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+	decryptionKey, err := deriveKey(kdfModule, password)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+
+	cipherText, err := aes128CTR(decryptionKey[:16], iv, sk)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+	cipherParamsByts, err := json.Marshal(&cipherParams{IV: hex.EncodeToString(iv)})
+	if err != nil {
+		return nil, fmt.Errorf("encrypt keystore: %s", err.Error())
+	}
+	checksum := sha256.Sum256(append(decryptionKey[16:32], cipherText...))
+
+	return &Keystore{
+		Crypto: &Crypto{
+			KDF:      kdfModule,
+			Checksum: &Module{Function: checksumFunction, Params: json.RawMessage("{}"), Message: hex.EncodeToString(checksum[:])},
+			Cipher:   &Module{Function: cipherFunction, Params: cipherParamsByts, Message: hex.EncodeToString(cipherText)},
+		},
+		PubKey:  hex.EncodeToString(pubKey),
+		Path:    path,
+		UUID:    uuid.New().String(),
+		Version: Version,
+	}, nil
+}
+
+// Decrypt returns the keystore's secret key (32 bytes, big endian)
+func (k *Keystore) Decrypt(password string) ([]byte, error) {
+	if k.Version != Version {
+		return nil, fmt.Errorf("decrypt keystore: unsupported version %d", k.Version)
+	}
+	if k.Crypto == nil || k.Crypto.KDF == nil || k.Crypto.Checksum == nil || k.Crypto.Cipher == nil {
+		return nil, fmt.Errorf("decrypt keystore: missing crypto module")
+	}
+	decryptionKey, err := deriveKey(k.Crypto.KDF, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: %s", err.Error())
+	}
+
+	cipherText, err := hex.DecodeString(k.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: cipher message: %s", err.Error())
+	}
+	if k.Crypto.Checksum.Function != checksumFunction {
+		return nil, fmt.Errorf("decrypt keystore: unsupported checksum %s", k.Crypto.Checksum.Function)
+	}
+	expectedChecksum, err := hex.DecodeString(k.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: checksum message: %s", err.Error())
+	}
+	checksum := sha256.Sum256(append(decryptionKey[16:32], cipherText...))
+	if !bytes.Equal(checksum[:], expectedChecksum) {
+		return nil, fmt.Errorf("decrypt keystore: invalid password")
+	}
+
+	if k.Crypto.Cipher.Function != cipherFunction {
+		return nil, fmt.Errorf("decrypt keystore: unsupported cipher %s", k.Crypto.Cipher.Function)
+	}
+	params := &cipherParams{}
+	if err := json.Unmarshal(k.Crypto.Cipher.Params, params); err != nil {
+		return nil, fmt.Errorf("decrypt keystore: cipher params: %s", err.Error())
+	}
+	iv, err := hex.DecodeString(params.IV)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: cipher iv: %s", err.Error())
+	}
+	sk, err := aes128CTR(decryptionKey[:16], iv, cipherText)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: %s", err.Error())
+	}
+
+	pubKey, err := publicKey(sk)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: %s", err.Error())
+	}
+	if k.PubKey != "" {
+		expected, err := k.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("decrypt keystore: %s", err.Error())
+		}
+		if !bytes.Equal(expected, pubKey) {
+			return nil, fmt.Errorf("decrypt keystore: secret key does not match pubkey %s", k.PubKey)
+		}
+	}
+	return sk, nil
+}
+
+// PublicKey returns the keystore's decoded pubkey
+func (k *Keystore) PublicKey() ([]byte, error) {
+	ret, err := hex.DecodeString(strings.TrimPrefix(k.PubKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("keystore pubkey: %s", err.Error())
+	}
+	if len(ret) != 48 {
+		return nil, fmt.Errorf("keystore pubkey: must be 48 bytes")
+	}
+	return ret, nil
+}
+
+// Load reads a keystore file
+func Load(file string) (*Keystore, error) {
+	byts, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("load keystore: %s", err.Error())
+	}
+	ret := &Keystore{}
+	if err := json.Unmarshal(byts, ret); err != nil {
+		return nil, fmt.Errorf("load keystore: %s", err.Error())
+	}
+	return ret, nil
+}
+
+// Write writes the keystore to file, readable by the owner only
+func (k *Keystore) Write(file string) error {
+	byts, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return fmt.Errorf("write keystore: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(file, byts, 0600); err != nil {
+		return fmt.Errorf("write keystore: %s", err.Error())
+	}
+	return nil
+}
+
+func deriveKey(kdf *Module, password string) ([]byte, error) {
+	switch kdf.Function {
+	case KDFScrypt:
+		params := &scryptParams{}
+		if err := json.Unmarshal(kdf.Params, params); err != nil {
+			return nil, fmt.Errorf("kdf params: %s", err.Error())
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("kdf salt: %s", err.Error())
+		}
+		if params.DKLen < 32 || params.DKLen > maxKDFDKLen {
+			return nil, fmt.Errorf("kdf dklen must be between 32 and %d", maxKDFDKLen)
+		}
+		if params.N <= 1 || params.N > maxScryptN {
+			return nil, fmt.Errorf("scrypt n must be between 2 and %d", maxScryptN)
+		}
+		if params.R < 1 || params.R > maxScryptR || params.P < 1 || params.P > maxScryptP {
+			return nil, fmt.Errorf("scrypt r must be between 1 and %d and p between 1 and %d", maxScryptR, maxScryptP)
+		}
+		return scrypt.Key(processPassword(password), salt, params.N, params.R, params.P, params.DKLen)
+	case KDFPBKDF2:
+		params := &pbkdf2Params{}
+		if err := json.Unmarshal(kdf.Params, params); err != nil {
+			return nil, fmt.Errorf("kdf params: %s", err.Error())
+		}
+		if params.PRF != prfFunction {
+			return nil, fmt.Errorf("unsupported prf %s", params.PRF)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("kdf salt: %s", err.Error())
+		}
+		if params.DKLen < 32 || params.DKLen > maxKDFDKLen {
+			return nil, fmt.Errorf("kdf dklen must be between 32 and %d", maxKDFDKLen)
+		}
+		if params.C < 1 || params.C > maxPBKDF2C {
+			return nil, fmt.Errorf("pbkdf2 c must be between 1 and %d", maxPBKDF2C)
+		}
+		return pbkdf2.Key(processPassword(password), salt, params.C, params.DKLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported kdf %s", kdf.Function)
+	}
+}
+
+// processPassword NFKD normalizes the password and strips control codes (C0, C1 and Delete)
+func processPassword(password string) []byte {
+	ret := make([]rune, 0)
+	for _, r := range norm.NFKD.String(password) {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		ret = append(ret, r)
+	}
+	return []byte(string(ret))
+}
+
+func aes128CTR(key []byte, iv []byte, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("iv must be %d bytes", block.BlockSize())
+	}
+	ret := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(ret, input)
+	return ret, nil
+}
+
+func publicKey(sk []byte) ([]byte, error) {
+	if len(sk) != 32 {
+		return nil, fmt.Errorf("secret key must be 32 bytes")
+	}
+	privKey := &bls.SecretKey{}
+	if err := privKey.Deserialize(sk); err != nil {
+		return nil, err
+	}
+	return privKey.GetPublicKey().Serialize(), nil
+}
@@ -0,0 +1,174 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+// test vectors from https://eips.ethereum.org/EIPS/eip-2335
+const (
+	vectorPassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	vectorSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	scryptVector = `{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {
+                "dklen": 32,
+                "n": 262144,
+                "p": 1,
+                "r": 8,
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592653/589793238",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`
+
+	pbkdf2Vector = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+)
+
+func init() {
+	// fast enough for tests
+	scryptN = 1 << 10
+	pbkdf2C = 1 << 10
+}
+
+func TestDecryptVectors(t *testing.T) {
+	for _, vector := range []string{scryptVector, pbkdf2Vector} {
+		keystore := &Keystore{}
+		require.NoError(t, json.Unmarshal([]byte(vector), keystore))
+
+		sk, err := keystore.Decrypt(vectorPassword)
+		require.NoError(t, err)
+		require.EqualValues(t, vectorSecret, hex.EncodeToString(sk))
+
+		_, err = keystore.Decrypt("testpassword")
+		require.EqualError(t, err, "decrypt keystore: invalid password")
+	}
+}
+
+func TestDecryptKDFLimits(t *testing.T) {
+	for _, test := range []struct {
+		vector string
+		old    string
+		new    string
+		err    string
+	}{
+		{scryptVector, `"n": 262144`, `"n": 2097152`, "decrypt keystore: scrypt n must be between 2 and 1048576"},
+		{scryptVector, `"r": 8`, `"r": 1024`, "decrypt keystore: scrypt r must be between 1 and 8 and p between 1 and 16"},
+		{scryptVector, `"p": 1`, `"p": 0`, "decrypt keystore: scrypt r must be between 1 and 8 and p between 1 and 16"},
+		{pbkdf2Vector, `"c": 262144`, `"c": 2097152`, "decrypt keystore: pbkdf2 c must be between 1 and 1048576"},
+		{pbkdf2Vector, `"dklen": 32`, `"dklen": 100000000`, "decrypt keystore: kdf dklen must be between 32 and 64"},
+	} {
+		keystore := &Keystore{}
+		require.NoError(t, json.Unmarshal([]byte(strings.Replace(test.vector, test.old, test.new, 1)), keystore))
+		_, err := keystore.Decrypt(vectorPassword)
+		require.EqualError(t, err, test.err)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	sk, err := hex.DecodeString(vectorSecret)
+	require.NoError(t, err)
+
+	for _, kdf := range []string{KDFScrypt, KDFPBKDF2} {
+		keystore, err := Encrypt(sk, "password", "m/12381/3600/0/0/0", kdf)
+		require.NoError(t, err)
+		require.EqualValues(t, "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07", keystore.PubKey)
+		require.EqualValues(t, kdf, keystore.Crypto.KDF.Function)
+
+		decrypted, err := keystore.Decrypt("password")
+		require.NoError(t, err)
+		require.EqualValues(t, sk, decrypted)
+		// control codes are stripped
+		decrypted, err = keystore.Decrypt("pass\x7fword\n")
+		require.NoError(t, err)
+		require.EqualValues(t, sk, decrypted)
+	}
+
+	_, err = Encrypt(sk, "password", "", "argon2")
+	require.EqualError(t, err, "encrypt keystore: unsupported kdf argon2")
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sk, err := hex.DecodeString(vectorSecret)
+	require.NoError(t, err)
+	keystore, err := Encrypt(sk, "password", "", KDFPBKDF2)
+	require.NoError(t, err)
+	require.NoError(t, keystore.Write(path.Join(dir, "keystore.json")))
+
+	loaded, err := Load(path.Join(dir, "keystore.json"))
+	require.NoError(t, err)
+	require.EqualValues(t, keystore.UUID, loaded.UUID)
+	decrypted, err := loaded.Decrypt("password")
+	require.NoError(t, err)
+	require.EqualValues(t, sk, decrypted)
+
+	// a 0x prefixed pubkey
+	loaded.PubKey = "0x" + loaded.PubKey
+	decrypted, err = loaded.Decrypt("password")
+	require.NoError(t, err)
+	require.EqualValues(t, sk, decrypted)
+	loaded.PubKey = "0x" + hex.EncodeToString(make([]byte, 48))
+	_, err = loaded.Decrypt("password")
+	require.EqualError(t, err, "decrypt keystore: secret key does not match pubkey "+loaded.PubKey)
+}
@@ -0,0 +1,151 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Manager holds keystores and the secret keys of the unlocked ones, the secret keys are never written anywhere.
+// A Manager is safe for concurrent use.
+type Manager struct {
+	lock      sync.RWMutex
+	keystores map[string]*Keystore
+	unlocked  map[string][]byte
+	signer    *signer.LocalSigner
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		keystores: make(map[string]*Keystore),
+		unlocked:  make(map[string][]byte),
+		signer:    &signer.LocalSigner{},
+	}
+}
+
+// LoadDir adds every keystore (*.json file) in dir
+func (m *Manager) LoadDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("load keystores: %s", err.Error())
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		keystore, err := Load(path.Join(dir, file.Name()))
+		if err != nil {
+			return fmt.Errorf("load keystores: %s: %s", file.Name(), err.Error())
+		}
+		if err := m.Add(keystore); err != nil {
+			return fmt.Errorf("load keystores: %s: %s", file.Name(), err.Error())
+		}
+	}
+	return nil
+}
+
+// Add adds a (locked) keystore
+func (m *Manager) Add(keystore *Keystore) error {
+	pubKey, err := keystore.PublicKey()
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.keystores[hex.EncodeToString(pubKey)] = keystore
+	return nil
+}
+
+// Unlock decrypts the keystore of pubKey with password
+func (m *Manager) Unlock(pubKey []byte, password string) error {
+	id := hex.EncodeToString(pubKey)
+	m.lock.RLock()
+	keystore, found := m.keystores[id]
+	m.lock.RUnlock()
+	if !found {
+		return fmt.Errorf("unlock: keystore for %s not found", id)
+	}
+
+	sk, err := keystore.Decrypt(password)
+	if err != nil {
+		return fmt.Errorf("unlock: %s", err.Error())
+	}
+
+	if _, err := m.signer.AddKey(sk); err != nil {
+		return fmt.Errorf("unlock: %s", err.Error())
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.unlocked[id] = sk
+	return nil
+}
+
+// UnlockAll decrypts all the keystores with password
+func (m *Manager) UnlockAll(password string) error {
+	for _, pubKey := range m.PubKeys() {
+		if err := m.Unlock(pubKey, password); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lock drops the secret key of pubKey from memory
+func (m *Manager) Lock(pubKey []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.signer.RemoveKey(pubKey)
+	id := hex.EncodeToString(pubKey)
+	if sk, found := m.unlocked[id]; found {
+		for i := range sk {
+			sk[i] = 0
+		}
+		delete(m.unlocked, id)
+	}
+}
+
+// PubKeys returns the pubkeys of all keystores
+func (m *Manager) PubKeys() [][]byte {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	ret := make([][]byte, 0, len(m.keystores))
+	for id := range m.keystores {
+		pubKey, _ := hex.DecodeString(id)
+		ret = append(ret, pubKey)
+	}
+	return ret
+}
+
+// IsUnlocked returns true if the keystore of pubKey is unlocked, so the manager's signer can sign with it
+func (m *Manager) IsUnlocked(pubKey []byte) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	_, found := m.unlocked[hex.EncodeToString(pubKey)]
+	return found
+}
+
+// SecretKey returns a copy of the secret key of an unlocked keystore in the format the SDK's signing functions take,
+// locking the keystore doesn't wipe the copy.
+func (m *Manager) SecretKey(pubKey []byte) ([]byte, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	sk, found := m.unlocked[hex.EncodeToString(pubKey)]
+	if !found {
+		return nil, fmt.Errorf("keystore for %s is locked", hex.EncodeToString(pubKey))
+	}
+	return append([]byte{}, sk...), nil
+}
+
+// Signer returns a signer of the unlocked keys, a key locked after the call can't sign anymore
+func (m *Manager) Signer() signer.Signer {
+	return m.signer
+}
@@ -0,0 +1,68 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystores")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sk, err := hex.DecodeString(vectorSecret)
+	require.NoError(t, err)
+	keystore, err := Encrypt(sk, "password", "", KDFScrypt)
+	require.NoError(t, err)
+	require.NoError(t, keystore.Write(path.Join(dir, "keystore-0.json")))
+	pubKey, err := keystore.PublicKey()
+	require.NoError(t, err)
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadDir(dir))
+	require.EqualValues(t, [][]byte{pubKey}, manager.PubKeys())
+
+	block := &core.Block{Slot: 1, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: &core.BlockBody{
+		RandaoReveal: make([]byte, 96),
+		Eth1Data:     &core.ETH1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+		Graffiti:     make([]byte, 32),
+	}}
+	state := &core.State{
+		Slot:                  1,
+		Fork:                  &core.Fork{PreviousVersion: make([]byte, 4), CurrentVersion: make([]byte, 4)},
+		GenesisValidatorsRoot: make([]byte, 32),
+	}
+	_, err = manager.Signer().SignBlock(state, block, pubKey)
+	require.EqualError(t, err, "local signer: no key for "+keystore.PubKey)
+
+	require.EqualError(t, manager.Unlock(pubKey, "wrong"), "unlock: decrypt keystore: invalid password")
+	require.False(t, manager.IsUnlocked(pubKey))
+	require.NoError(t, manager.UnlockAll("password"))
+	require.True(t, manager.IsUnlocked(pubKey))
+	sig, err := manager.Signer().SignBlock(state, block, pubKey)
+	require.NoError(t, err)
+	root, err := signer.BlockSigningRoot(state, block)
+	require.NoError(t, err)
+	res, err := shared.VerifySignature(root[:], pubKey, sig)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	// locking doesn't wipe the returned secret key
+	unlockedSK, err := manager.SecretKey(pubKey)
+	require.NoError(t, err)
+	manager.Lock(pubKey)
+	require.EqualValues(t, sk, unlockedSK)
+	_, err = manager.SecretKey(pubKey)
+	require.Error(t, err)
+	require.False(t, manager.IsUnlocked(pubKey))
+	_, err = manager.Signer().SignBlock(state, block, pubKey)
+	require.Error(t, err)
+	require.EqualError(t, manager.Unlock(make([]byte, 48), "password"), "unlock: keystore for "+hex.EncodeToString(make([]byte, 48))+" not found")
+}
@@ -0,0 +1,63 @@
+package oppool
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+type aggregationGroup struct {
+	bits    bitfield.Bitlist
+	members []*core.Attestation
+}
+
+// aggregate merges attestations with the same data greedily, each attestation joins the first group it doesn't
+// overlap with. Groups that fail to aggregate are returned as their individual attestations.
+func aggregate(state *core.State, attestations []*core.Attestation) []*core.Attestation {
+	groups := make(map[[32]byte][]*aggregationGroup)
+	order := make([][32]byte, 0)
+	for _, att := range attestations {
+		root, err := att.Data.HashTreeRoot()
+		if err != nil {
+			continue
+		}
+		if _, found := groups[root]; !found {
+			order = append(order, root)
+		}
+
+		joined := false
+		for _, group := range groups[root] {
+			if group.bits.Len() != att.AggregationBits.Len() || group.bits.Overlaps(att.AggregationBits) {
+				continue
+			}
+			group.bits = group.bits.Or(att.AggregationBits)
+			group.members = append(group.members, att)
+			joined = true
+			break
+		}
+		if !joined {
+			groups[root] = append(groups[root], &aggregationGroup{
+				bits:    att.AggregationBits.Or(att.AggregationBits),
+				members: []*core.Attestation{att},
+			})
+		}
+	}
+
+	ret := make([]*core.Attestation, 0, len(attestations))
+	for _, root := range order {
+		for _, group := range groups[root] {
+			if len(group.members) == 1 {
+				ret = append(ret, group.members[0])
+				continue
+			}
+			aggregated, err := state_transition.AggregateAttestations(state, group.members...)
+			if err != nil {
+				ret = append(ret, group.members...)
+				continue
+			}
+			ret = append(ret, aggregated)
+		}
+	}
+	sortAttestations(ret)
+	return ret
+}
@@ -0,0 +1,243 @@
+package oppool
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"sort"
+)
+
+// Prune drops operations that were included in state or can no longer be included in a block on top of it:
+// attestations outside the inclusion window or before the finalized epoch, slashings of validators that
+// can't be slashed, exits of validators that already exit and processed deposits.
+func (p *Pool) Prune(state *core.State) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	currentEpoch := shared.GetCurrentEpoch(state)
+	for root, att := range p.attestations {
+		if isStaleAttestation(state, att) || isIncludedAttestation(state, att) {
+			delete(p.attestations, root)
+		}
+	}
+	for index := range p.proposerSlashings {
+		validator := shared.GetValidator(state, index)
+		if validator == nil || !shared.IsSlashableValidator(validator, currentEpoch) {
+			delete(p.proposerSlashings, index)
+		}
+	}
+	for root, slashing := range p.attesterSlashings {
+		if !hasSlashableIndices(state, slashing) {
+			delete(p.attesterSlashings, root)
+		}
+	}
+	for index := range p.voluntaryExits {
+		validator := shared.GetValidator(state, index)
+		if validator == nil || validator.ExitEpoch != params.ChainConfig.FarFutureEpoch {
+			delete(p.voluntaryExits, index)
+		}
+	}
+	for index := range p.deposits {
+		if index < state.Eth1DepositIndex {
+			delete(p.deposits, index)
+		}
+	}
+}
+
+// FillBlockBody prunes the pool and sets the operations of a block proposed on top of state (already advanced
+// to the block's slot). body.Eth1Data must be set to the block's eth1 vote, the vote is applied first as the
+// deposits the block must include depend on it. Operations are applied to a copy of state in block order and
+// only those that apply are selected, up to the per block limits. Returns an error if the pool is missing
+// deposits the block must include.
+func (p *Pool) FillBlockBody(state *core.State, body *core.BlockBody) error {
+	if body.Eth1Data == nil {
+		return fmt.Errorf("fill block body: body is missing eth1 data")
+	}
+	p.Prune(state)
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	stateCopy := shared.CopyState(state)
+	if err := state_transition.ProcessEth1Data(stateCopy, body); err != nil {
+		return fmt.Errorf("fill block body: %s", err.Error())
+	}
+
+	proposerSlashings := make([]*core.ProposerSlashing, 0)
+	proposerIndices := make([]uint64, 0, len(p.proposerSlashings))
+	for index := range p.proposerSlashings {
+		proposerIndices = append(proposerIndices, index)
+	}
+	for _, index := range sortIndices(proposerIndices) {
+		if uint64(len(proposerSlashings)) == params.ChainConfig.MaxProposerSlashings {
+			break
+		}
+		slashing := p.proposerSlashings[index]
+		if err := state_transition.ProcessProposerSlashing(stateCopy, slashing); err != nil {
+			continue
+		}
+		proposerSlashings = append(proposerSlashings, slashing)
+	}
+
+	attesterSlashings := make([]*core.AttesterSlashing, 0)
+	attesterSlashingRoots := make([][32]byte, 0, len(p.attesterSlashings))
+	for root := range p.attesterSlashings {
+		attesterSlashingRoots = append(attesterSlashingRoots, root)
+	}
+	for _, root := range sortRoots(attesterSlashingRoots) {
+		if uint64(len(attesterSlashings)) == params.ChainConfig.MaxAttesterSlashings {
+			break
+		}
+		slashing := p.attesterSlashings[root]
+		if !hasSlashableIndices(stateCopy, slashing) {
+			continue
+		}
+		if err := state_transition.ProcessAttesterSlashing(stateCopy, slashing); err != nil {
+			continue
+		}
+		attesterSlashings = append(attesterSlashings, slashing)
+	}
+
+	attestations, err := PackAttestations(stateCopy, aggregate(state, p.sortedAttestations()), params.ChainConfig.MaxAttestations)
+	if err != nil {
+		return fmt.Errorf("fill block body: %s", err.Error())
+	}
+	for _, att := range attestations {
+		if err := state_transition.ProcessAttestationNoSigVerify(stateCopy, att); err != nil {
+			return fmt.Errorf("fill block body: %s", err.Error())
+		}
+	}
+
+	// deposits are mandatory and processed in order, up to the deposit count of the eth1 data after the vote
+	deposits := make([]*core.Deposit, 0)
+	firstIndex := stateCopy.Eth1DepositIndex
+	depositCount := mathutil.Min(params.ChainConfig.MaxDeposits, stateCopy.Eth1Data.DepositCount-firstIndex)
+	for index := firstIndex; index < firstIndex+depositCount; index++ {
+		deposit, found := p.deposits[index]
+		if !found {
+			return fmt.Errorf("fill block body: missing deposit %d", index)
+		}
+		// deposits added before the vote were not verified against its deposit root
+		if err := state_transition.VerifyDepositMerkleBranch(stateCopy.Eth1Data.DepositRoot, index, deposit); err != nil {
+			return fmt.Errorf("fill block body: deposit %d: %s", index, err.Error())
+		}
+		if err := state_transition.ProcessDeposit(stateCopy, deposit); err != nil {
+			return fmt.Errorf("fill block body: deposit %d: %s", index, err.Error())
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	voluntaryExits := make([]*core.SignedVoluntaryExit, 0)
+	exitIndices := make([]uint64, 0, len(p.voluntaryExits))
+	for index := range p.voluntaryExits {
+		exitIndices = append(exitIndices, index)
+	}
+	for _, index := range sortIndices(exitIndices) {
+		if uint64(len(voluntaryExits)) == params.ChainConfig.MaxVoluntaryExits {
+			break
+		}
+		exit := p.voluntaryExits[index]
+		if err := state_transition.ProcessVoluntaryExit(stateCopy, exit); err != nil {
+			continue
+		}
+		voluntaryExits = append(voluntaryExits, exit)
+	}
+
+	body.ProposerSlashings = proposerSlashings
+	body.AttesterSlashings = attesterSlashings
+	body.Attestations = attestations
+	body.Deposits = deposits
+	body.VoluntaryExits = voluntaryExits
+	return nil
+}
+
+// newest attestations first, then the ones with more attesters
+func (p *Pool) sortedAttestations() []*core.Attestation {
+	roots := make([][32]byte, 0, len(p.attestations))
+	for root := range p.attestations {
+		roots = append(roots, root)
+	}
+	ret := make([]*core.Attestation, 0, len(roots))
+	for _, root := range sortRoots(roots) {
+		ret = append(ret, p.attestations[root])
+	}
+	sortAttestations(ret)
+	return ret
+}
+
+func sortAttestations(attestations []*core.Attestation) {
+	sort.SliceStable(attestations, func(i, j int) bool {
+		if attestations[i].Data.Slot != attestations[j].Data.Slot {
+			return attestations[i].Data.Slot > attestations[j].Data.Slot
+		}
+		return attestations[i].AggregationBits.Count() > attestations[j].AggregationBits.Count()
+	})
+}
+
+// the attestation can't be included in a block on top of state anymore
+func isStaleAttestation(state *core.State, att *core.Attestation) bool {
+	if att.Data.Target.Epoch < shared.GetPreviousEpoch(state) {
+		return true
+	}
+	if att.Data.Target.Epoch < state.FinalizedCheckpoint.Epoch {
+		return true
+	}
+	return state.Slot > att.Data.Slot+params.ChainConfig.SlotsInEpoch
+}
+
+// all of the attestation's attesters are included in state with the same attestation data
+func isIncludedAttestation(state *core.State, att *core.Attestation) bool {
+	dataRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+
+	var included bitfield.Bitlist
+	for _, pendingAttestations := range [][]*core.PendingAttestation{state.PreviousEpochAttestations, state.CurrentEpochAttestations} {
+		for _, pending := range pendingAttestations {
+			if pending.AggregationBits.Len() != att.AggregationBits.Len() {
+				continue
+			}
+			pendingRoot, err := pending.Data.HashTreeRoot()
+			if err != nil || pendingRoot != dataRoot {
+				continue
+			}
+			if included == nil {
+				included = pending.AggregationBits
+			} else {
+				included = pending.AggregationBits.Or(included)
+			}
+		}
+	}
+	if included == nil {
+		return false
+	}
+	return included.Contains(att.AggregationBits)
+}
+
+func hasSlashableIndices(state *core.State, slashing *core.AttesterSlashing) bool {
+	indices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+	for _, index := range indices {
+		validator := shared.GetValidator(state, index)
+		if validator != nil && shared.IsSlashableValidator(validator, shared.GetCurrentEpoch(state)) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortIndices(indices []uint64) []uint64 {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func sortRoots(roots [][32]byte) [][32]byte {
+	sort.Slice(roots, func(i, j int) bool { return bytes.Compare(roots[i][:], roots[j][:]) < 0 })
+	return roots
+}
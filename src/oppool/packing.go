@@ -0,0 +1,152 @@
+package oppool
+
+import (
+	"container/heap"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+)
+
+// PackAttestations selects up to max attestations for a block proposed on top of state (already advanced to the
+// block's slot), maximizing the proposer's reward.
+//
+// get_inclusion_delay_deltas rewards the proposer of the attestation with the minimal inclusion delay for every
+// unslashed attester, an attester that was already included (in the state or by a previously selected
+// attestation) earns the proposer nothing. This makes the selection a weighted max coverage problem, solved
+// greedily (the best achievable approximation) by picking the attestation covering the most reward each time.
+// Marginal rewards only decrease as attestations are picked so they are re-evaluated lazily.
+//
+// Invalid attestations and attestations that add no reward are skipped.
+func PackAttestations(state *core.State, candidates []*core.Attestation, max uint64) ([]*core.Attestation, error) {
+	packer := &attestationPacker{
+		state:   state,
+		covered: make(map[uint64]map[uint64]bool),
+		rewards: make(map[uint64]uint64),
+	}
+	for _, pendingAttestations := range [][]*core.PendingAttestation{state.PreviousEpochAttestations, state.CurrentEpochAttestations} {
+		for _, pending := range pendingAttestations {
+			indices, err := shared.GetAttestingIndices(state, pending.Data, pending.AggregationBits)
+			if err != nil {
+				return nil, err
+			}
+			packer.cover(pending.Data.Target.Epoch, indices)
+		}
+	}
+
+	queue := &packingQueue{}
+	stateCopy := shared.CopyState(state)
+	for i, att := range candidates {
+		if err := state_transition.ProcessAttestationNoSigVerify(stateCopy, att); err != nil {
+			continue
+		}
+		indices, err := shared.GetAttestingIndices(state, att.Data, att.AggregationBits)
+		if err != nil {
+			continue
+		}
+		item := &packingItem{attestation: att, indices: indices, order: i}
+		if item.reward, err = packer.reward(item); err != nil {
+			return nil, err
+		}
+		if item.reward > 0 {
+			queue.items = append(queue.items, item)
+		}
+	}
+	heap.Init(queue)
+
+	ret := make([]*core.Attestation, 0)
+	for queue.Len() > 0 && uint64(len(ret)) < max {
+		item := heap.Pop(queue).(*packingItem)
+		reward, err := packer.reward(item)
+		if err != nil {
+			return nil, err
+		}
+		if reward == 0 {
+			continue
+		}
+		// another attestation might be better now, re-queue with the updated reward
+		if reward < item.reward && queue.Len() > 0 && queue.items[0].reward > reward {
+			item.reward = reward
+			heap.Push(queue, item)
+			continue
+		}
+
+		packer.cover(item.attestation.Data.Target.Epoch, item.indices)
+		ret = append(ret, item.attestation)
+	}
+	return ret, nil
+}
+
+type attestationPacker struct {
+	state *core.State
+	// target epoch -> attesters already included
+	covered map[uint64]map[uint64]bool
+	// proposer reward by effective balance, get_base_reward depends on nothing else
+	rewards map[uint64]uint64
+}
+
+func (p *attestationPacker) cover(epoch uint64, indices []uint64) {
+	if p.covered[epoch] == nil {
+		p.covered[epoch] = make(map[uint64]bool)
+	}
+	for _, index := range indices {
+		p.covered[epoch][index] = true
+	}
+}
+
+// the proposer reward for the attesters the item adds
+func (p *attestationPacker) reward(item *packingItem) (uint64, error) {
+	covered := p.covered[item.attestation.Data.Target.Epoch]
+	ret := uint64(0)
+	for _, index := range item.indices {
+		if covered[index] {
+			continue
+		}
+		validator := shared.GetValidator(p.state, index)
+		if validator == nil || validator.Slashed {
+			continue
+		}
+		reward, found := p.rewards[validator.EffectiveBalance]
+		if !found {
+			var err error
+			if reward, err = shared.GetProposerReward(p.state, index); err != nil {
+				return 0, err
+			}
+			p.rewards[validator.EffectiveBalance] = reward
+		}
+		ret += reward
+	}
+	return ret, nil
+}
+
+type packingItem struct {
+	attestation *core.Attestation
+	indices     []uint64
+	// last computed marginal reward
+	reward uint64
+	// position in the candidates, breaks ties
+	order int
+}
+
+// max heap by reward
+type packingQueue struct {
+	items []*packingItem
+}
+
+func (q *packingQueue) Len() int { return len(q.items) }
+
+func (q *packingQueue) Less(i, j int) bool {
+	if q.items[i].reward != q.items[j].reward {
+		return q.items[i].reward > q.items[j].reward
+	}
+	return q.items[i].order < q.items[j].order
+}
+
+func (q *packingQueue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+
+func (q *packingQueue) Push(x interface{}) { q.items = append(q.items, x.(*packingItem)) }
+
+func (q *packingQueue) Pop() interface{} {
+	item := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return item
+}
@@ -0,0 +1,50 @@
+package oppool
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPackAttestations(t *testing.T) {
+	state, keys := genesisState(t)
+	attState := advance(t, state, 1)
+	a := attestation(t, attState, keys, 1, 0, 1)
+	b := attestation(t, attState, keys, 1, 1, 2)
+	c := attestation(t, attState, keys, 1, 2, 3)
+	blockState := advance(t, state, 2)
+
+	// a and c cover the whole committee, a and b (the first by size) only 3 of it
+	packed, err := PackAttestations(blockState, []*core.Attestation{a, b, c}, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, []*core.Attestation{a, c}, packed)
+
+	// b adds nothing once a and c are packed
+	packed, err = PackAttestations(blockState, []*core.Attestation{a, b, c}, 3)
+	require.NoError(t, err)
+	require.EqualValues(t, []*core.Attestation{a, c}, packed)
+
+	// attesters included in the state earn nothing
+	included := shared.CopyState(blockState)
+	require.NoError(t, state_transition.ProcessAttestationNoSigVerify(included, attestation(t, attState, keys, 1, 0)))
+	packed, err = PackAttestations(included, []*core.Attestation{a, b, c}, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, []*core.Attestation{b, c}, packed)
+
+	// slashed attesters earn nothing
+	slashed := shared.CopyState(blockState)
+	committee, err := shared.GetBeaconCommittee(slashed, 1, 0)
+	require.NoError(t, err)
+	slashed.Validators[committee[2]].Slashed = true
+	slashed.Validators[committee[3]].Slashed = true
+	packed, err = PackAttestations(slashed, []*core.Attestation{c, b, a}, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, []*core.Attestation{a}, packed)
+
+	// invalid attestations are skipped
+	packed, err = PackAttestations(attState, []*core.Attestation{a, b, c}, 2)
+	require.NoError(t, err)
+	require.Len(t, packed, 0)
+}
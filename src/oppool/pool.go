@@ -0,0 +1,169 @@
+package oppool
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"sync"
+)
+
+// Pool holds operations received from the network until they are included in a block.
+// Every operation is validated against the state it's added with, operations that were included or
+// became stale are dropped by Prune.
+type Pool struct {
+	lock sync.RWMutex
+
+	// keyed by hash tree root
+	attestations map[[32]byte]*core.Attestation
+	// keyed by the slashed proposer's index
+	proposerSlashings map[uint64]*core.ProposerSlashing
+	// keyed by hash tree root
+	attesterSlashings map[[32]byte]*core.AttesterSlashing
+	// keyed by the exiting validator's index
+	voluntaryExits map[uint64]*core.SignedVoluntaryExit
+	// keyed by the deposit's index in the deposit contract
+	deposits map[uint64]*core.Deposit
+}
+
+func NewPool() *Pool {
+	return &Pool{
+		attestations:      make(map[[32]byte]*core.Attestation),
+		proposerSlashings: make(map[uint64]*core.ProposerSlashing),
+		attesterSlashings: make(map[[32]byte]*core.AttesterSlashing),
+		voluntaryExits:    make(map[uint64]*core.SignedVoluntaryExit),
+		deposits:          make(map[uint64]*core.Deposit),
+	}
+}
+
+// AddAttestation validates the attestation (including its signature) and adds it to the pool.
+// Attestations that can't be included yet (MIN_ATTESTATION_INCLUSION_DELAY) are validated as if
+// state was at the first slot they can be included in, attestations for slots after state's are rejected.
+func (p *Pool) AddAttestation(state *core.State, attestation *core.Attestation) error {
+	if attestation == nil || attestation.Data == nil || attestation.Data.Target == nil || attestation.Data.Source == nil {
+		return fmt.Errorf("add attestation: attestation is missing data")
+	}
+	if attestation.Data.Slot > state.Slot {
+		return fmt.Errorf("add attestation: slot %d after the state's slot %d", attestation.Data.Slot, state.Slot)
+	}
+
+	stateCopy := shared.CopyState(state)
+	if inclusionSlot := attestation.Data.Slot + params.ChainConfig.MinAttestationInclusionDelay; stateCopy.Slot < inclusionSlot {
+		stateCopy.Slot = inclusionSlot
+	}
+	if err := state_transition.ProcessAttestationNoSigVerify(stateCopy, attestation); err != nil {
+		return fmt.Errorf("add attestation: %s", err.Error())
+	}
+	indexed, err := shared.GetIndexedAttestation(stateCopy, attestation)
+	if err != nil {
+		return fmt.Errorf("add attestation: %s", err.Error())
+	}
+	if valid, err := shared.IsValidIndexedAttestation(stateCopy, indexed); !valid || err != nil {
+		if err != nil {
+			return fmt.Errorf("add attestation: %s", err.Error())
+		}
+		return fmt.Errorf("add attestation: signature not verified")
+	}
+
+	root, err := attestation.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("add attestation: %s", err.Error())
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.attestations[root] = attestation
+	return nil
+}
+
+// AddProposerSlashing validates the slashing and adds it to the pool, only one slashing per proposer is kept.
+func (p *Pool) AddProposerSlashing(state *core.State, slashing *core.ProposerSlashing) error {
+	if slashing == nil || slashing.Header_1 == nil || slashing.Header_2 == nil {
+		return fmt.Errorf("add proposer slashing: slashing is missing headers")
+	}
+	if err := state_transition.ProcessProposerSlashing(shared.CopyState(state), slashing); err != nil {
+		return fmt.Errorf("add proposer slashing: %s", err.Error())
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.proposerSlashings[slashing.Header_1.Header.ProposerIndex] = slashing
+	return nil
+}
+
+// AddAttesterSlashing validates the slashing and adds it to the pool.
+func (p *Pool) AddAttesterSlashing(state *core.State, slashing *core.AttesterSlashing) error {
+	if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+		return fmt.Errorf("add attester slashing: slashing is missing attestations")
+	}
+	if err := state_transition.ProcessAttesterSlashing(shared.CopyState(state), slashing); err != nil {
+		return fmt.Errorf("add attester slashing: %s", err.Error())
+	}
+
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("add attester slashing: %s", err.Error())
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.attesterSlashings[root] = slashing
+	return nil
+}
+
+// AddVoluntaryExit validates the exit and adds it to the pool, only one exit per validator is kept.
+func (p *Pool) AddVoluntaryExit(state *core.State, exit *core.SignedVoluntaryExit) error {
+	if exit == nil || exit.Exit == nil {
+		return fmt.Errorf("add voluntary exit: exit is missing")
+	}
+	if err := state_transition.ProcessVoluntaryExit(shared.CopyState(state), exit); err != nil {
+		return fmt.Errorf("add voluntary exit: %s", err.Error())
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.voluntaryExits[exit.Exit.ValidatorIndex] = exit
+	return nil
+}
+
+// AddDeposit adds the deposit at index (its index in the deposit contract) to the pool. Its proof is verified
+// if the state's eth1 data already includes it, otherwise it's verified once a block includes it.
+func (p *Pool) AddDeposit(state *core.State, index uint64, deposit *core.Deposit) error {
+	if deposit == nil || deposit.Data == nil {
+		return fmt.Errorf("add deposit: deposit is missing data")
+	}
+	if index < state.Eth1DepositIndex {
+		return fmt.Errorf("add deposit: deposit %d already processed", index)
+	}
+	if index < state.Eth1Data.DepositCount {
+		if err := state_transition.VerifyDepositMerkleBranch(state.Eth1Data.DepositRoot, index, deposit); err != nil {
+			return fmt.Errorf("add deposit: %s", err.Error())
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.deposits[index] = deposit
+	return nil
+}
+
+// Attestations returns all the attestations in the pool
+func (p *Pool) Attestations() []*core.Attestation {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	ret := make([]*core.Attestation, 0, len(p.attestations))
+	for _, att := range p.attestations {
+		ret = append(ret, att)
+	}
+	return ret
+}
+
+// Size returns the number of operations in the pool
+func (p *Pool) Size() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return len(p.attestations) + len(p.proposerSlashings) + len(p.attesterSlashings) + len(p.voluntaryExits) + len(p.deposits)
+}
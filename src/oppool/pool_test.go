@@ -0,0 +1,229 @@
+package oppool
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/genesis"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func genesisState(t *testing.T) (*core.State, []*bls.SecretKey) {
+	state, keys, err := genesis.InteropGenesisState(0, params.ChainConfig.MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+	return state, keys
+}
+
+func advance(t *testing.T, state *core.State, slot uint64) *core.State {
+	ret := shared.CopyState(state)
+	require.NoError(t, state_transition.NewStateTransition().ProcessSlots(ret, slot))
+	return ret
+}
+
+// an attestation for committee 0 at slot signed by the committee members at positions
+func attestation(t *testing.T, state *core.State, keys []*bls.SecretKey, slot uint64, positions ...int) *core.Attestation {
+	epoch := shared.ComputeEpochAtSlot(slot)
+	data := &core.AttestationData{
+		Slot:            slot,
+		CommitteeIndex:  0,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          state.CurrentJustifiedCheckpoint,
+		Target:          &core.Checkpoint{Epoch: epoch, Root: make([]byte, 32)},
+	}
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconAttester, epoch)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	committee, err := shared.GetBeaconCommittee(state, slot, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	sig := &bls.Sign{}
+	for i, position := range positions {
+		bits.SetBitAt(uint64(position), true)
+		if i == 0 {
+			sig = keys[committee[position]].SignByte(root[:])
+		} else {
+			sig.Add(keys[committee[position]].SignByte(root[:]))
+		}
+	}
+	return &core.Attestation{AggregationBits: bits, Data: data, Signature: sig.Serialize()}
+}
+
+func signedHeader(t *testing.T, state *core.State, key *bls.SecretKey, proposer uint64, bodyRoot byte) *core.SignedBlockHeader {
+	header := &core.BlockHeader{
+		Slot:          state.Slot,
+		ProposerIndex: proposer,
+		ParentRoot:    make([]byte, 32),
+		StateRoot:     make([]byte, 32),
+		BodyRoot:      make([]byte, 32),
+	}
+	header.BodyRoot[0] = bodyRoot
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconProposer, shared.GetCurrentEpoch(state))
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(header, domain)
+	require.NoError(t, err)
+	return &core.SignedBlockHeader{Header: header, Signature: key.SignByte(root[:]).Serialize()}
+}
+
+func voluntaryExit(t *testing.T, state *core.State, key *bls.SecretKey, index uint64) *core.SignedVoluntaryExit {
+	exit := &core.VoluntaryExit{Epoch: shared.GetCurrentEpoch(state), ValidatorIndex: index}
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainVoluntaryExit, exit.Epoch)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(exit, domain)
+	require.NoError(t, err)
+	return &core.SignedVoluntaryExit{Exit: exit, Signature: key.SignByte(root[:]).Serialize()}
+}
+
+func TestAttestations(t *testing.T) {
+	state, keys := genesisState(t)
+	state = advance(t, state, 1)
+	pool := NewPool()
+
+	// can't be included before the next slot but is kept
+	full := attestation(t, state, keys, 1, 0, 1)
+	partial := attestation(t, state, keys, 1, 0)
+	require.NoError(t, pool.AddAttestation(state, full))
+	require.NoError(t, pool.AddAttestation(state, partial))
+
+	invalid := attestation(t, state, keys, 1, 0)
+	invalid.Signature = full.Signature
+	require.EqualError(t, pool.AddAttestation(state, invalid), "add attestation: indexed attestation signature not vrified")
+	invalid = attestation(t, state, keys, 1, 0)
+	invalid.Data.Source = &core.Checkpoint{Epoch: 1, Root: make([]byte, 32)}
+	require.EqualError(t, pool.AddAttestation(state, invalid), "add attestation: source doesn't equal current justified checkpoint")
+	future := attestation(t, advance(t, state, 2), keys, 2, 0)
+	require.EqualError(t, pool.AddAttestation(state, future), "add attestation: slot 2 after the state's slot 1")
+	require.Equal(t, 2, pool.Size())
+
+	// the partial attestation is covered by the full one
+	blockState := advance(t, state, 2)
+	body := &core.BlockBody{Eth1Data: blockState.Eth1Data}
+	require.NoError(t, pool.FillBlockBody(blockState, body))
+	require.Len(t, body.Attestations, 1)
+	require.EqualValues(t, full, body.Attestations[0])
+	require.Len(t, body.ProposerSlashings, 0)
+	require.Len(t, body.Deposits, 0)
+
+	// included attestations are pruned
+	require.NoError(t, state_transition.ProcessAttestationNoSigVerify(blockState, full))
+	pool.Prune(blockState)
+	require.Equal(t, 0, pool.Size())
+
+	// stale attestations are pruned
+	require.NoError(t, pool.AddAttestation(state, partial))
+	pool.Prune(advance(t, state, 2+params.ChainConfig.SlotsInEpoch))
+	require.Equal(t, 0, pool.Size())
+}
+
+func TestSlashingsAndExits(t *testing.T) {
+	state, keys := genesisState(t)
+	// validators can exit once they were active for SHARD_COMMITTEE_PERIOD
+	state.Slot = params.ChainConfig.ShardCommitteePeriod * params.ChainConfig.SlotsInEpoch
+	pool := NewPool()
+
+	slashing := &core.ProposerSlashing{
+		Header_1: signedHeader(t, state, keys[1], 1, 1),
+		Header_2: signedHeader(t, state, keys[1], 1, 2),
+	}
+	require.NoError(t, pool.AddProposerSlashing(state, slashing))
+	require.Error(t, pool.AddProposerSlashing(state, &core.ProposerSlashing{
+		Header_1: signedHeader(t, state, keys[1], 1, 1),
+		Header_2: signedHeader(t, state, keys[1], 1, 1),
+	}))
+
+	require.NoError(t, pool.AddVoluntaryExit(state, voluntaryExit(t, state, keys[1], 1)))
+	require.NoError(t, pool.AddVoluntaryExit(state, voluntaryExit(t, state, keys[2], 2)))
+	require.NoError(t, pool.AddVoluntaryExit(state, voluntaryExit(t, state, keys[3], 3)))
+	require.Error(t, pool.AddVoluntaryExit(state, voluntaryExit(t, state, keys[4], 5)))
+
+	// the slashed proposer exits with the slashing
+	maxExits := params.ChainConfig.MaxVoluntaryExits
+	params.ChainConfig.MaxVoluntaryExits = 1
+	defer func() { params.ChainConfig.MaxVoluntaryExits = maxExits }()
+	body := &core.BlockBody{Eth1Data: state.Eth1Data}
+	require.NoError(t, pool.FillBlockBody(state, body))
+	require.EqualValues(t, []*core.ProposerSlashing{slashing}, body.ProposerSlashings)
+	require.Len(t, body.VoluntaryExits, 1)
+	require.EqualValues(t, 2, body.VoluntaryExits[0].Exit.ValidatorIndex)
+
+	// once processed they are pruned
+	require.NoError(t, state_transition.ProcessProposerSlashings(state, body.ProposerSlashings))
+	require.NoError(t, state_transition.ProcessExits(state, body.VoluntaryExits))
+	pool.Prune(state)
+	require.Equal(t, 1, pool.Size())
+}
+
+func TestDeposits(t *testing.T) {
+	state, _ := genesisState(t)
+	count := params.ChainConfig.MinGenesisActiveValidatorCount
+	keys, err := genesis.InteropSecretKeys(count + 2)
+	require.NoError(t, err)
+	data, err := genesis.InteropDepositData(keys)
+	require.NoError(t, err)
+	deposits, err := genesis.DepositsWithProofs(data)
+	require.NoError(t, err)
+	pool := NewPool()
+
+	require.EqualError(t, pool.AddDeposit(state, 0, deposits[0]), "add deposit: deposit 0 already processed")
+	// not in the state's eth1 data yet, verified once included
+	require.NoError(t, pool.AddDeposit(state, count, deposits[count]))
+
+	// eth1 data voted in with one new deposit
+	leaves := make([][]byte, count+1)
+	for i := range leaves {
+		root, err := data[i].HashTreeRoot()
+		require.NoError(t, err)
+		leaves[i] = root[:]
+	}
+	trie, err := trieutil.GenerateTrieFromItems(leaves, int(params.ChainConfig.DepositContractTreeDepth))
+	require.NoError(t, err)
+	depositRoot := trie.Root()
+	eth1Data := &core.ETH1Data{DepositRoot: depositRoot[:], DepositCount: count + 1, BlockHash: make([]byte, 32)}
+
+	// the block's vote reaches majority, the block must include the new deposit
+	votingState := shared.CopyState(state)
+	for i := uint64(0); i < params.ChainConfig.EpochsPerETH1VotingPeriod*params.ChainConfig.SlotsInEpoch/2; i++ {
+		votingState.Eth1DataVotes = append(votingState.Eth1DataVotes, eth1Data)
+	}
+	body := &core.BlockBody{Eth1Data: eth1Data}
+	require.NoError(t, pool.FillBlockBody(votingState, body))
+	require.EqualValues(t, []*core.Deposit{deposits[count]}, body.Deposits)
+	body = &core.BlockBody{Eth1Data: state.Eth1Data}
+	require.NoError(t, pool.FillBlockBody(votingState, body))
+	require.Len(t, body.Deposits, 0)
+
+	state.Eth1Data = eth1Data
+	require.Error(t, pool.AddDeposit(state, count, deposits[count+1]))
+	body = &core.BlockBody{Eth1Data: eth1Data}
+	require.NoError(t, pool.FillBlockBody(state, body))
+	require.EqualValues(t, []*core.Deposit{deposits[count]}, body.Deposits)
+
+	// the block must include the new deposit
+	pool = NewPool()
+	require.EqualError(t, pool.FillBlockBody(state, body), "fill block body: missing deposit 16")
+	require.EqualError(t, pool.FillBlockBody(state, &core.BlockBody{}), "fill block body: body is missing eth1 data")
+}
+
+func TestAggregatedAttestations(t *testing.T) {
+	state, keys := genesisState(t)
+	state = advance(t, state, 1)
+	pool := NewPool()
+
+	// 0+1 and 2 aggregate, 1+2 overlaps both
+	require.NoError(t, pool.AddAttestation(state, attestation(t, state, keys, 1, 0, 1)))
+	require.NoError(t, pool.AddAttestation(state, attestation(t, state, keys, 1, 2)))
+	require.NoError(t, pool.AddAttestation(state, attestation(t, state, keys, 1, 1, 2)))
+
+	blockState := advance(t, state, 2)
+	body := &core.BlockBody{Eth1Data: blockState.Eth1Data}
+	require.NoError(t, pool.FillBlockBody(blockState, body))
+	require.Len(t, body.Attestations, 1)
+	require.EqualValues(t, []int{0, 1, 2}, body.Attestations[0].AggregationBits.BitIndices())
+	require.NoError(t, state_transition.ProcessBlockAttestations(blockState, body.Attestations))
+}
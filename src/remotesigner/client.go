@@ -0,0 +1,192 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	PubKeysPath = "/api/v1/eth2/publicKeys"
+	SignPath    = "/api/v1/eth2/sign/"
+	UpcheckPath = "/upcheck"
+)
+
+// Client is a signer.Signer that has a remote signer (e.g. Web3Signer or Server) sign every message. The signing
+// root is computed locally and sent with the typed message so the remote signer can verify it and apply its own
+// slashing protection.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the remote signer at url, httpClient is http.DefaultClient if nil
+func NewClient(url string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{url: strings.TrimSuffix(url, "/"), httpClient: httpClient}
+}
+
+// PubKeys returns the keys the remote signer holds
+func (c *Client) PubKeys() ([][]byte, error) {
+	resp, err := c.httpClient.Get(c.url + PubKeysPath)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if err := responseError(resp); err != nil {
+		return nil, err
+	}
+
+	var pubKeys []HexBytes
+	if err := json.NewDecoder(resp.Body).Decode(&pubKeys); err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	ret := make([][]byte, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		ret[i] = pubKey
+	}
+	return ret, nil
+}
+
+func (c *Client) SignBlock(state *core.State, block *core.Block, pubKey []byte) ([]byte, error) {
+	root, err := signer.BlockSigningRoot(state, block)
+	if err != nil {
+		return nil, fmt.Errorf("sign block: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:        TypeBlock,
+		ForkInfo:    forkInfo(state),
+		SigningRoot: root[:],
+		BeaconBlock: &BeaconBlock{Version: blockVersionPhase0, Block: beaconBlock(block)},
+	})
+}
+
+func (c *Client) SignRandaoReveal(state *core.State, epoch uint64, pubKey []byte) ([]byte, error) {
+	root, err := signer.RandaoSigningRoot(state, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("sign randao reveal: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:         TypeRandaoReveal,
+		ForkInfo:     forkInfo(state),
+		SigningRoot:  root[:],
+		RandaoReveal: &RandaoReveal{Epoch: epoch},
+	})
+}
+
+func (c *Client) SignAttestationData(state *core.State, data *core.AttestationData, pubKey []byte) ([]byte, error) {
+	root, err := signer.AttestationDataSigningRoot(state, data)
+	if err != nil {
+		return nil, fmt.Errorf("sign attestation data: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:        TypeAttestation,
+		ForkInfo:    forkInfo(state),
+		SigningRoot: root[:],
+		Attestation: attestationData(data),
+	})
+}
+
+func (c *Client) SignSelectionProof(state *core.State, slot uint64, pubKey []byte) ([]byte, error) {
+	root, err := signer.SelectionProofSigningRoot(state, slot)
+	if err != nil {
+		return nil, fmt.Errorf("sign selection proof: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:            TypeAggregationSlot,
+		ForkInfo:        forkInfo(state),
+		SigningRoot:     root[:],
+		AggregationSlot: &AggregationSlot{Slot: slot},
+	})
+}
+
+func (c *Client) SignAggregateAndProof(state *core.State, msg *core.AggregateAndProof, pubKey []byte) ([]byte, error) {
+	root, err := signer.AggregateAndProofSigningRoot(state, msg)
+	if err != nil {
+		return nil, fmt.Errorf("sign aggregate and proof: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:              TypeAggregateAndProof,
+		ForkInfo:          forkInfo(state),
+		SigningRoot:       root[:],
+		AggregateAndProof: aggregateAndProof(msg),
+	})
+}
+
+func (c *Client) SignVoluntaryExit(state *core.State, exit *core.VoluntaryExit, pubKey []byte) ([]byte, error) {
+	root, err := signer.VoluntaryExitSigningRoot(state, exit)
+	if err != nil {
+		return nil, fmt.Errorf("sign voluntary exit: %s", err.Error())
+	}
+	return c.sign(pubKey, &SignRequest{
+		Type:          TypeVoluntaryExit,
+		ForkInfo:      forkInfo(state),
+		SigningRoot:   root[:],
+		VoluntaryExit: &VoluntaryExit{Epoch: exit.Epoch, ValidatorIndex: exit.ValidatorIndex},
+	})
+}
+
+func (c *Client) SignDeposit(msg *core.DepositMessage) ([]byte, error) {
+	root, err := signer.DepositSigningRoot(msg)
+	if err != nil {
+		return nil, fmt.Errorf("sign deposit: %s", err.Error())
+	}
+	return c.sign(msg.PublicKey, &SignRequest{
+		Type:        TypeDeposit,
+		SigningRoot: root[:],
+		Deposit: &Deposit{
+			PubKey:                msg.PublicKey,
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                msg.Amount,
+			GenesisForkVersion:    params.ChainConfig.GenesisForkVersion,
+		},
+	})
+}
+
+func (c *Client) sign(pubKey []byte, req *SignRequest) ([]byte, error) {
+	byts, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, c.url+SignPath+"0x"+hex.EncodeToString(pubKey), bytes.NewReader(byts))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if err := responseError(resp); err != nil {
+		return nil, err
+	}
+
+	ret := &SignResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(ret); err != nil {
+		return nil, fmt.Errorf("remote signer: %s", err.Error())
+	}
+	if len(ret.Signature) != 96 {
+		return nil, fmt.Errorf("remote signer: signature must be 96 bytes")
+	}
+	return ret.Signature, nil
+}
+
+func responseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("remote signer: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
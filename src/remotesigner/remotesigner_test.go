@@ -0,0 +1,265 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keystore"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/slashingprotection"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/state_transition"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// a remote signer of the test context's validator keys
+func testServer(t *testing.T, dir string) *httptest.Server {
+	manager := keystore.NewManager()
+	for i := uint64(0); i < params.ChainConfig.MinGenesisActiveValidatorCount; i++ {
+		// the test keys as 32 bytes big endian
+		sk := make([]byte, 32)
+		testKey := []byte(fmt.Sprintf("%d", i))
+		copy(sk[32-len(testKey):], testKey)
+		encrypted, err := keystore.Encrypt(sk, "password", "", keystore.KDFPBKDF2)
+		require.NoError(t, err)
+		require.NoError(t, manager.Add(encrypted))
+	}
+	require.NoError(t, manager.UnlockAll("password"))
+	protection, err := slashingprotection.NewStore(dir)
+	require.NoError(t, err)
+	return httptest.NewServer(NewServer(manager, protection))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := state_transition.NewStateTestContext(params.ChainConfig, nil, 0)
+	ctx.PopulateGenesisValidator(params.ChainConfig.MinGenesisActiveValidatorCount)
+	state := ctx.State
+	st := state_transition.NewStateTransition()
+	require.NoError(t, st.ProcessSlots(state, 2))
+
+	server := testServer(t, dir)
+	defer server.Close()
+	client := NewClient(server.URL, nil)
+
+	pubKeys, err := client.PubKeys()
+	require.NoError(t, err)
+	require.Len(t, pubKeys, len(state.Validators))
+
+	// a block built with the remote signer passes the state transition
+	stateCopy := shared.CopyState(state)
+	require.NoError(t, st.ProcessSlots(stateCopy, state.Slot+1))
+	proposer, err := shared.GetBlockProposerIndex(stateCopy)
+	require.NoError(t, err)
+	proposerKey := state.Validators[proposer].PublicKey
+	randaoReveal, err := client.SignRandaoReveal(stateCopy, shared.GetCurrentEpoch(stateCopy), proposerKey)
+	require.NoError(t, err)
+	body := &core.BlockBody{
+		RandaoReveal: randaoReveal,
+		Attestations: []*core.Attestation{},
+		Eth1Data:     state.Eth1Data,
+		Graffiti:     make([]byte, 32),
+	}
+	signedBlock, err := st.BuildBlock(state, state.Slot+1, body, client)
+	require.NoError(t, err)
+	_, err = st.ExecuteStateTransition(state, signedBlock, true)
+	require.NoError(t, err)
+
+	// the same signatures as local keys
+	pubKey := state.Validators[0].PublicKey
+	data := &core.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          state.CurrentJustifiedCheckpoint,
+		Target:          &core.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+	}
+	aggregateAndProof := &core.AggregateAndProof{
+		AggregatorIndex: 0,
+		Aggregate:       &core.Attestation{AggregationBits: []byte{3}, Data: data, Signature: make([]byte, 96)},
+		SelectionProof:  make([]byte, 96),
+	}
+	exit := &core.VoluntaryExit{Epoch: 0, ValidatorIndex: 0}
+	deposit := &core.DepositMessage{PublicKey: pubKey, WithdrawalCredentials: make([]byte, 32), Amount: 32 * 1e9}
+	tests := []struct {
+		name string
+		sign func(s signer.Signer) ([]byte, error)
+	}{
+		{
+			name: "attestation data",
+			sign: func(s signer.Signer) ([]byte, error) { return s.SignAttestationData(state, data, pubKey) },
+		},
+		{
+			name: "selection proof",
+			sign: func(s signer.Signer) ([]byte, error) { return s.SignSelectionProof(state, 1, pubKey) },
+		},
+		{
+			name: "aggregate and proof",
+			sign: func(s signer.Signer) ([]byte, error) {
+				return s.SignAggregateAndProof(state, aggregateAndProof, pubKey)
+			},
+		},
+		{
+			name: "voluntary exit",
+			sign: func(s signer.Signer) ([]byte, error) { return s.SignVoluntaryExit(state, exit, pubKey) },
+		},
+		{
+			name: "deposit",
+			sign: func(s signer.Signer) ([]byte, error) { return s.SignDeposit(deposit) },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remote, err := test.sign(client)
+			require.NoError(t, err)
+			local, err := test.sign(ctx.Signer)
+			require.NoError(t, err)
+			require.EqualValues(t, local, remote)
+		})
+	}
+
+	// slashing protection, signing the same attestation again is allowed
+	_, err = client.SignAttestationData(state, data, pubKey)
+	require.NoError(t, err)
+	doubleVote := &core.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: bytes.Repeat([]byte{1}, 32),
+		Source:          state.CurrentJustifiedCheckpoint,
+		Target:          &core.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+	}
+	_, err = client.SignAttestationData(state, doubleVote, pubKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "remote signer: 412 Precondition Failed")
+
+	// unknown keys
+	_, err = client.SignAttestationData(state, data, make([]byte, 48))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "remote signer: 404 Not Found")
+
+	// a signing root that doesn't match the message
+	byts := []byte(`{"type":"RANDAO_REVEAL","fork_info":{"fork":{"previous_version":"0x00000000","current_version":"0x00000000","epoch":"0"},"genesis_validators_root":"0x` + hex.EncodeToString(state.GenesisValidatorsRoot) + `"},"signingRoot":"0x` + hex.EncodeToString(make([]byte, 32)) + `","randao_reveal":{"epoch":"0"}}`)
+	resp, err := http.Post(server.URL+SignPath+"0x"+hex.EncodeToString(pubKey), "application/json", bytes.NewReader(byts))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.EqualValues(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// a phase0 BLOCK_V2 request in the shape Web3Signer documents (https://consensys.github.io/web3signer/web3signer-eth2.html),
+// the block goes whole under beacon_block.block
+const web3SignerBlockRequest = `{
+  "type": "BLOCK_V2",
+  "fork_info": {
+    "fork": {
+      "previous_version": "0x00000001",
+      "current_version": "0x00000001",
+      "epoch": "1"
+    },
+    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+  },
+  "beacon_block": {
+    "version": "PHASE0",
+    "block": {
+      "slot": "9",
+      "proposer_index": "5",
+      "parent_root": "0xb2eedb01adbd02c828d5eec09b4c70cbba12ffffba525ebf48aca33028e8ad89",
+      "state_root": "0x2b530d6262576277f1cc0dbe341fd919f9f8c5c92fc9140dff6db4ef34edea0d",
+      "body": {
+        "randao_reveal": "0xa686652aed2617da83adebb8a0eceea24bb0d2ccec9cd691a902087f90db16aa5c7b03172a35e874e07e3b60c5b2435c0586b72b08dfe5aee0ed6e5a2922b956aa88ad0235b36dfaa4d2255dfeb7bed60578d982061a72c7549becab19b3c12f",
+        "eth1_data": {
+          "deposit_root": "0x6a0f9d6cb0868daa22c365563bb113b05f7568ef9ee65fdfeb49a319eaf708cf",
+          "deposit_count": "8",
+          "block_hash": "0x4242424242424242424242424242424242424242424242424242424242424242"
+        },
+        "graffiti": "0x74656b752f76302e31322e31302d6465762d6338316361363235000000000000",
+        "proposer_slashings": [],
+        "attester_slashings": [],
+        "attestations": [
+          {
+            "aggregation_bits": "0x03",
+            "data": {
+              "slot": "8",
+              "index": "0",
+              "beacon_block_root": "0xb2eedb01adbd02c828d5eec09b4c70cbba12ffffba525ebf48aca33028e8ad89",
+              "source": {
+                "epoch": "0",
+                "root": "0x0000000000000000000000000000000000000000000000000000000000000000"
+              },
+              "target": {
+                "epoch": "1",
+                "root": "0xb2eedb01adbd02c828d5eec09b4c70cbba12ffffba525ebf48aca33028e8ad89"
+              }
+            },
+            "signature": "0x967dd2946358db7e426ed19d4576bc75123520ef6a489ca50002222070ee4611f9cef394e5e3071236a93b825f18a4ad07f1d5a1405e6c984f1d71e03f535d13a2156d6b22a75efc4a0d9a8a2d3d1c52ffa0cd2d5c4e1a58d4f5ea8e5c1f3bd3"
+          }
+        ],
+        "deposits": [],
+        "voluntary_exits": [
+          {
+            "message": {
+              "epoch": "1",
+              "validator_index": "3"
+            },
+            "signature": "0xabababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababab"
+          }
+        ]
+      }
+    }
+  }
+}`
+
+func TestBlockRequestShape(t *testing.T) {
+	req := &SignRequest{}
+	require.NoError(t, json.Unmarshal([]byte(web3SignerBlockRequest), req))
+	block, err := req.BeaconBlock.Block.toCore()
+	require.NoError(t, err)
+	require.EqualValues(t, 9, block.Slot)
+	require.EqualValues(t, 5, block.Proposer)
+	require.EqualValues(t, 8, block.Body.Eth1Data.DepositCount)
+	require.Len(t, block.Body.Attestations, 1)
+	require.EqualValues(t, 1, block.Body.Attestations[0].Data.Target.Epoch)
+	require.Len(t, block.Body.VoluntaryExits, 1)
+	require.EqualValues(t, 3, block.Body.VoluntaryExits[0].Exit.ValidatorIndex)
+
+	// the client sends the same body
+	state := &core.State{
+		Fork: &core.Fork{
+			PreviousVersion: []byte{0, 0, 0, 1},
+			CurrentVersion:  []byte{0, 0, 0, 1},
+			Epoch:           1,
+		},
+		GenesisValidatorsRoot: req.ForkInfo.GenesisValidatorsRoot,
+	}
+	byts, err := json.Marshal(&SignRequest{
+		Type:        TypeBlock,
+		ForkInfo:    forkInfo(state),
+		BeaconBlock: &BeaconBlock{Version: blockVersionPhase0, Block: beaconBlock(block)},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, web3SignerBlockRequest, string(byts))
+
+	// and the server signs the block's signing root
+	msg, err := parseMessage(make([]byte, 48), req)
+	require.NoError(t, err)
+	state.Slot = block.Slot
+	root, err := signer.BlockSigningRoot(state, block)
+	require.NoError(t, err)
+	require.EqualValues(t, root, msg.root)
+
+	// block_header is only valid from bellatrix
+	header := strings.Replace(web3SignerBlockRequest, `"block": {`, `"block_header": {`, 1)
+	req = &SignRequest{}
+	require.NoError(t, json.Unmarshal([]byte(header), req))
+	_, err = parseMessage(make([]byte, 48), req)
+	require.EqualError(t, err, "missing beacon_block.block")
+}
@@ -0,0 +1,293 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/keystore"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/slashingprotection"
+	"net/http"
+	"strings"
+)
+
+// Server serves Web3Signer compatible signing requests with the unlocked keys of a keystore manager, a stand-in for
+// a signing service to run the remote signing flow on one machine. Every signing root is recomputed from the typed
+// message, blocks and attestations are checked against the slashing protection store (if any) before signing.
+type Server struct {
+	manager    *keystore.Manager
+	protection *slashingprotection.Store
+}
+
+// NewServer returns a server of manager's unlocked keys, protection may be nil to sign without slashing protection
+func NewServer(manager *keystore.Manager, protection *slashingprotection.Store) *Server {
+	return &Server{manager: manager, protection: protection}
+}
+
+// an error and the http status it is served with
+type serverError struct {
+	status int
+	err    error
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == UpcheckPath:
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("OK"))
+	case r.Method == http.MethodGet && r.URL.Path == PubKeysPath:
+		s.pubKeys(w)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, SignPath):
+		sig, err := s.sign(r, strings.TrimPrefix(r.URL.Path, SignPath))
+		if err != nil {
+			http.Error(w, err.err.Error(), err.status)
+			return
+		}
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			writeJSON(w, &SignResponse{Signature: sig})
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("0x" + hex.EncodeToString(sig)))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// the keys the server can sign with
+func (s *Server) pubKeys(w http.ResponseWriter) {
+	ret := make([]HexBytes, 0)
+	for _, pubKey := range s.manager.PubKeys() {
+		if s.manager.IsUnlocked(pubKey) {
+			ret = append(ret, pubKey)
+		}
+	}
+	writeJSON(w, ret)
+}
+
+func (s *Server) sign(r *http.Request, id string) ([]byte, *serverError) {
+	pubKey := HexBytes{}
+	if err := pubKey.UnmarshalText([]byte(id)); err != nil || len(pubKey) != 48 {
+		return nil, &serverError{http.StatusBadRequest, fmt.Errorf("invalid pubkey %s", id)}
+	}
+	if !s.manager.IsUnlocked(pubKey) {
+		return nil, &serverError{http.StatusNotFound, fmt.Errorf("keystore for %s is locked", hex.EncodeToString(pubKey))}
+	}
+
+	req := &SignRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, &serverError{http.StatusBadRequest, fmt.Errorf("invalid request: %s", err.Error())}
+	}
+	msg, sErr := s.checkMessage(pubKey, req)
+	if sErr != nil {
+		return nil, sErr
+	}
+	sig, err := msg.sign(s.manager.Signer(), pubKey)
+	if err != nil {
+		return nil, &serverError{http.StatusInternalServerError, err}
+	}
+	return sig, nil
+}
+
+// a request's typed message, its signing root and how a signer signs it
+type message struct {
+	root [32]byte
+	sign func(keySigner signer.Signer, pubKey []byte) ([]byte, error)
+}
+
+// checkMessage recomputes the signing root of the request's message, it must match the requester's (if sent), and
+// runs blocks and attestations through slashing protection
+func (s *Server) checkMessage(pubKey []byte, req *SignRequest) (*message, *serverError) {
+	msg, err := parseMessage(pubKey, req)
+	if err != nil {
+		return nil, &serverError{http.StatusBadRequest, err}
+	}
+	if req.SigningRoot != nil && !bytes.Equal(req.SigningRoot, msg.root[:]) {
+		return nil, &serverError{http.StatusBadRequest, fmt.Errorf("signing root %s does not match the message's %s",
+			hex.EncodeToString(req.SigningRoot), hex.EncodeToString(msg.root[:]))}
+	}
+
+	if s.protection == nil {
+		return msg, nil
+	}
+	switch req.Type {
+	case TypeBlock:
+		block := &core.Block{Slot: req.BeaconBlock.Block.Slot}
+		if err := s.protection.CheckAndRecordBlock(pubKey, block, msg.root); err != nil {
+			return nil, &serverError{http.StatusPreconditionFailed, err}
+		}
+	case TypeAttestation:
+		data, _ := req.Attestation.toCore()
+		if err := s.protection.CheckAndRecordAttestation(pubKey, data, msg.root); err != nil {
+			return nil, &serverError{http.StatusPreconditionFailed, err}
+		}
+	}
+	return msg, nil
+}
+
+func parseMessage(pubKey []byte, req *SignRequest) (*message, error) {
+	ret := &message{}
+	switch req.Type {
+	case TypeBlock:
+		if req.BeaconBlock == nil || req.BeaconBlock.Block == nil {
+			return nil, fmt.Errorf("missing beacon_block.block")
+		}
+		if req.BeaconBlock.Version != blockVersionPhase0 {
+			return nil, fmt.Errorf("unsupported block version %s", req.BeaconBlock.Version)
+		}
+		block, err := req.BeaconBlock.Block.toCore()
+		if err != nil {
+			return nil, err
+		}
+		state, err := signingState(req.ForkInfo, shared.ComputeEpochAtSlot(block.Slot))
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.BlockSigningRoot(state, block)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignBlock(state, block, pubKey)
+		}
+	case TypeAttestation:
+		if req.Attestation == nil {
+			return nil, fmt.Errorf("missing attestation")
+		}
+		data, err := req.Attestation.toCore()
+		if err != nil {
+			return nil, err
+		}
+		state, err := signingState(req.ForkInfo, data.Target.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.AttestationDataSigningRoot(state, data)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignAttestationData(state, data, pubKey)
+		}
+	case TypeRandaoReveal:
+		if req.RandaoReveal == nil {
+			return nil, fmt.Errorf("missing randao_reveal")
+		}
+		epoch := req.RandaoReveal.Epoch
+		state, err := signingState(req.ForkInfo, epoch)
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.RandaoSigningRoot(state, epoch)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignRandaoReveal(state, epoch, pubKey)
+		}
+	case TypeAggregationSlot:
+		if req.AggregationSlot == nil {
+			return nil, fmt.Errorf("missing aggregation_slot")
+		}
+		slot := req.AggregationSlot.Slot
+		state, err := signingState(req.ForkInfo, shared.ComputeEpochAtSlot(slot))
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.SelectionProofSigningRoot(state, slot)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignSelectionProof(state, slot, pubKey)
+		}
+	case TypeAggregateAndProof:
+		if req.AggregateAndProof == nil {
+			return nil, fmt.Errorf("missing aggregate_and_proof")
+		}
+		msg, err := req.AggregateAndProof.toCore()
+		if err != nil {
+			return nil, err
+		}
+		state, err := signingState(req.ForkInfo, shared.ComputeEpochAtSlot(msg.Aggregate.Data.Slot))
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.AggregateAndProofSigningRoot(state, msg)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignAggregateAndProof(state, msg, pubKey)
+		}
+	case TypeVoluntaryExit:
+		if req.VoluntaryExit == nil {
+			return nil, fmt.Errorf("missing voluntary_exit")
+		}
+		exit := &core.VoluntaryExit{Epoch: req.VoluntaryExit.Epoch, ValidatorIndex: req.VoluntaryExit.ValidatorIndex}
+		state, err := signingState(req.ForkInfo, exit.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		ret.root, err = signer.VoluntaryExitSigningRoot(state, exit)
+		if err != nil {
+			return nil, err
+		}
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignVoluntaryExit(state, exit, pubKey)
+		}
+	case TypeDeposit:
+		if req.Deposit == nil {
+			return nil, fmt.Errorf("missing deposit")
+		}
+		// the signer signs deposits with the configured genesis fork version and the deposit's own pubkey
+		if !bytes.Equal(req.Deposit.GenesisForkVersion, params.ChainConfig.GenesisForkVersion) {
+			return nil, fmt.Errorf("unsupported genesis fork version %s", hex.EncodeToString(req.Deposit.GenesisForkVersion))
+		}
+		if !bytes.Equal(req.Deposit.PubKey, pubKey) {
+			return nil, fmt.Errorf("deposit pubkey does not match the signing key")
+		}
+		msg := &core.DepositMessage{
+			PublicKey:             req.Deposit.PubKey,
+			WithdrawalCredentials: req.Deposit.WithdrawalCredentials,
+			Amount:                req.Deposit.Amount,
+		}
+		root, err := signer.DepositSigningRoot(msg)
+		if err != nil {
+			return nil, err
+		}
+		ret.root = root
+		ret.sign = func(keySigner signer.Signer, pubKey []byte) ([]byte, error) {
+			return keySigner.SignDeposit(msg)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", req.Type)
+	}
+	return ret, nil
+}
+
+// signingState returns a state at the start of epoch with the request's fork info, shared.GetDomain picks the fork
+// version by the state's epoch
+func signingState(info *ForkInfo, epoch uint64) (*core.State, error) {
+	if info == nil || info.Fork == nil {
+		return nil, fmt.Errorf("missing fork_info")
+	}
+	return &core.State{
+		Slot: epoch * params.ChainConfig.SlotsInEpoch,
+		Fork: &core.Fork{
+			PreviousVersion: info.Fork.PreviousVersion,
+			CurrentVersion:  info.Fork.CurrentVersion,
+			Epoch:           info.Fork.Epoch,
+		},
+		GenesisValidatorsRoot: info.GenesisValidatorsRoot,
+	}, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,497 @@
+package remotesigner
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"strconv"
+	"strings"
+)
+
+// Web3Signer compatible signing requests, https://consensys.github.io/web3signer/web3signer-eth2.html
+// Numbers are decimal strings and byte fields 0x prefixed hex, as in the eth2 API.
+
+// The request types, one per message
+const (
+	TypeBlock             = "BLOCK_V2"
+	TypeAttestation       = "ATTESTATION"
+	TypeRandaoReveal      = "RANDAO_REVEAL"
+	TypeAggregationSlot   = "AGGREGATION_SLOT"
+	TypeAggregateAndProof = "AGGREGATE_AND_PROOF"
+	TypeVoluntaryExit     = "VOLUNTARY_EXIT"
+	TypeDeposit           = "DEPOSIT"
+
+	// the only block version the SDK signs
+	blockVersionPhase0 = "PHASE0"
+)
+
+// SignRequest is the body of a sign request. SigningRoot is the root the requester computed, the server recomputes
+// it from the typed message and fork info and refuses to sign if they differ.
+type SignRequest struct {
+	Type              string             `json:"type"`
+	ForkInfo          *ForkInfo          `json:"fork_info,omitempty"`
+	SigningRoot       HexBytes           `json:"signingRoot,omitempty"`
+	BeaconBlock       *BeaconBlock       `json:"beacon_block,omitempty"`
+	Attestation       *AttestationData   `json:"attestation,omitempty"`
+	RandaoReveal      *RandaoReveal      `json:"randao_reveal,omitempty"`
+	AggregationSlot   *AggregationSlot   `json:"aggregation_slot,omitempty"`
+	AggregateAndProof *AggregateAndProof `json:"aggregate_and_proof,omitempty"`
+	VoluntaryExit     *VoluntaryExit     `json:"voluntary_exit,omitempty"`
+	Deposit           *Deposit           `json:"deposit,omitempty"`
+}
+
+// SignResponse is the body of a successful sign request
+type SignResponse struct {
+	Signature HexBytes `json:"signature"`
+}
+
+type ForkInfo struct {
+	Fork                  *Fork    `json:"fork"`
+	GenesisValidatorsRoot HexBytes `json:"genesis_validators_root"`
+}
+
+type Fork struct {
+	PreviousVersion HexBytes `json:"previous_version"`
+	CurrentVersion  HexBytes `json:"current_version"`
+	Epoch           uint64   `json:"epoch,string"`
+}
+
+// BeaconBlock is a versioned block. Web3Signer takes phase0 (and altair) blocks whole under block, block_header is
+// only accepted from bellatrix.
+type BeaconBlock struct {
+	Version string `json:"version"`
+	Block   *Block `json:"block"`
+}
+
+type Block struct {
+	Slot          uint64     `json:"slot,string"`
+	ProposerIndex uint64     `json:"proposer_index,string"`
+	ParentRoot    HexBytes   `json:"parent_root"`
+	StateRoot     HexBytes   `json:"state_root"`
+	Body          *BlockBody `json:"body"`
+}
+
+type BlockBody struct {
+	RandaoReveal      HexBytes               `json:"randao_reveal"`
+	Eth1Data          *Eth1Data              `json:"eth1_data"`
+	Graffiti          HexBytes               `json:"graffiti"`
+	ProposerSlashings []*ProposerSlashing    `json:"proposer_slashings"`
+	AttesterSlashings []*AttesterSlashing    `json:"attester_slashings"`
+	Attestations      []*Attestation         `json:"attestations"`
+	Deposits          []*BlockDeposit        `json:"deposits"`
+	VoluntaryExits    []*SignedVoluntaryExit `json:"voluntary_exits"`
+}
+
+type Eth1Data struct {
+	DepositRoot  HexBytes `json:"deposit_root"`
+	DepositCount uint64   `json:"deposit_count,string"`
+	BlockHash    HexBytes `json:"block_hash"`
+}
+
+type BlockHeader struct {
+	Slot          uint64   `json:"slot,string"`
+	ProposerIndex uint64   `json:"proposer_index,string"`
+	ParentRoot    HexBytes `json:"parent_root"`
+	StateRoot     HexBytes `json:"state_root"`
+	BodyRoot      HexBytes `json:"body_root"`
+}
+
+type SignedBlockHeader struct {
+	Message   *BlockHeader `json:"message"`
+	Signature HexBytes     `json:"signature"`
+}
+
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBlockHeader `json:"signed_header_1"`
+	SignedHeader2 *SignedBlockHeader `json:"signed_header_2"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []Uint64         `json:"attesting_indices"`
+	Data             *AttestationData `json:"data"`
+	Signature        HexBytes         `json:"signature"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+// BlockDeposit is a deposit included in a block, Deposit is the deposit message signing request
+type BlockDeposit struct {
+	Proof []HexBytes   `json:"proof"`
+	Data  *DepositData `json:"data"`
+}
+
+type DepositData struct {
+	PubKey                HexBytes `json:"pubkey"`
+	WithdrawalCredentials HexBytes `json:"withdrawal_credentials"`
+	Amount                uint64   `json:"amount,string"`
+	Signature             HexBytes `json:"signature"`
+}
+
+type SignedVoluntaryExit struct {
+	Message   *VoluntaryExit `json:"message"`
+	Signature HexBytes       `json:"signature"`
+}
+
+type Checkpoint struct {
+	Epoch uint64   `json:"epoch,string"`
+	Root  HexBytes `json:"root"`
+}
+
+type AttestationData struct {
+	Slot            uint64      `json:"slot,string"`
+	Index           uint64      `json:"index,string"`
+	BeaconBlockRoot HexBytes    `json:"beacon_block_root"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+type Attestation struct {
+	AggregationBits HexBytes         `json:"aggregation_bits"`
+	Data            *AttestationData `json:"data"`
+	Signature       HexBytes         `json:"signature"`
+}
+
+type RandaoReveal struct {
+	Epoch uint64 `json:"epoch,string"`
+}
+
+type AggregationSlot struct {
+	Slot uint64 `json:"slot,string"`
+}
+
+type AggregateAndProof struct {
+	AggregatorIndex uint64       `json:"aggregator_index,string"`
+	Aggregate       *Attestation `json:"aggregate"`
+	SelectionProof  HexBytes     `json:"selection_proof"`
+}
+
+type VoluntaryExit struct {
+	Epoch          uint64 `json:"epoch,string"`
+	ValidatorIndex uint64 `json:"validator_index,string"`
+}
+
+type Deposit struct {
+	PubKey                HexBytes `json:"pubkey"`
+	WithdrawalCredentials HexBytes `json:"withdrawal_credentials"`
+	Amount                uint64   `json:"amount,string"`
+	GenesisForkVersion    HexBytes `json:"genesis_fork_version"`
+}
+
+// Uint64 is a number encoded as a decimal string, for lists (the string option only applies to fields)
+type Uint64 uint64
+
+func (u Uint64) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(u), 10)), nil
+}
+
+func (u *Uint64) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseUint(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	*u = Uint64(v)
+	return nil
+}
+
+// HexBytes is a byte field encoded as 0x prefixed hex
+type HexBytes []byte
+
+func (b HexBytes) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+func (b *HexBytes) UnmarshalText(text []byte) error {
+	str := string(text)
+	if !strings.HasPrefix(str, "0x") {
+		return fmt.Errorf("hex value %s must be 0x prefixed", str)
+	}
+	byts, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return err
+	}
+	*b = byts
+	return nil
+}
+
+func forkInfo(state *core.State) *ForkInfo {
+	return &ForkInfo{
+		Fork: &Fork{
+			PreviousVersion: state.Fork.PreviousVersion,
+			CurrentVersion:  state.Fork.CurrentVersion,
+			Epoch:           state.Fork.Epoch,
+		},
+		GenesisValidatorsRoot: state.GenesisValidatorsRoot,
+	}
+}
+
+func beaconBlock(block *core.Block) *Block {
+	body := block.Body
+	ret := &BlockBody{
+		RandaoReveal:      body.RandaoReveal,
+		Graffiti:          body.Graffiti,
+		ProposerSlashings: make([]*ProposerSlashing, 0, len(body.ProposerSlashings)),
+		AttesterSlashings: make([]*AttesterSlashing, 0, len(body.AttesterSlashings)),
+		Attestations:      make([]*Attestation, 0, len(body.Attestations)),
+		Deposits:          make([]*BlockDeposit, 0, len(body.Deposits)),
+		VoluntaryExits:    make([]*SignedVoluntaryExit, 0, len(body.VoluntaryExits)),
+	}
+	if body.Eth1Data != nil {
+		ret.Eth1Data = &Eth1Data{
+			DepositRoot:  body.Eth1Data.DepositRoot,
+			DepositCount: body.Eth1Data.DepositCount,
+			BlockHash:    body.Eth1Data.BlockHash,
+		}
+	}
+	for _, slashing := range body.ProposerSlashings {
+		ret.ProposerSlashings = append(ret.ProposerSlashings, &ProposerSlashing{
+			SignedHeader1: signedBlockHeader(slashing.Header_1),
+			SignedHeader2: signedBlockHeader(slashing.Header_2),
+		})
+	}
+	for _, slashing := range body.AttesterSlashings {
+		ret.AttesterSlashings = append(ret.AttesterSlashings, &AttesterSlashing{
+			Attestation1: indexedAttestation(slashing.Attestation_1),
+			Attestation2: indexedAttestation(slashing.Attestation_2),
+		})
+	}
+	for _, att := range body.Attestations {
+		ret.Attestations = append(ret.Attestations, attestation(att))
+	}
+	for _, deposit := range body.Deposits {
+		proof := make([]HexBytes, len(deposit.Proof))
+		for i, p := range deposit.Proof {
+			proof[i] = p
+		}
+		ret.Deposits = append(ret.Deposits, &BlockDeposit{
+			Proof: proof,
+			Data: &DepositData{
+				PubKey:                deposit.Data.PublicKey,
+				WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
+				Amount:                deposit.Data.Amount,
+				Signature:             deposit.Data.Signature,
+			},
+		})
+	}
+	for _, exit := range body.VoluntaryExits {
+		ret.VoluntaryExits = append(ret.VoluntaryExits, &SignedVoluntaryExit{
+			Message:   &VoluntaryExit{Epoch: exit.Exit.Epoch, ValidatorIndex: exit.Exit.ValidatorIndex},
+			Signature: exit.Signature,
+		})
+	}
+	return &Block{
+		Slot:          block.Slot,
+		ProposerIndex: block.Proposer,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body:          ret,
+	}
+}
+
+func (b *Block) toCore() (*core.Block, error) {
+	if b.Body == nil || b.Body.Eth1Data == nil {
+		return nil, fmt.Errorf("block: missing body")
+	}
+	body := b.Body
+	ret := &core.BlockBody{
+		RandaoReveal: body.RandaoReveal,
+		Eth1Data: &core.ETH1Data{
+			DepositRoot:  body.Eth1Data.DepositRoot,
+			DepositCount: body.Eth1Data.DepositCount,
+			BlockHash:    body.Eth1Data.BlockHash,
+		},
+		Graffiti: body.Graffiti,
+	}
+	for _, slashing := range body.ProposerSlashings {
+		if slashing == nil {
+			return nil, fmt.Errorf("block: missing proposer slashing")
+		}
+		header1, err := slashing.SignedHeader1.toCore()
+		if err != nil {
+			return nil, err
+		}
+		header2, err := slashing.SignedHeader2.toCore()
+		if err != nil {
+			return nil, err
+		}
+		ret.ProposerSlashings = append(ret.ProposerSlashings, &core.ProposerSlashing{Header_1: header1, Header_2: header2})
+	}
+	for _, slashing := range body.AttesterSlashings {
+		if slashing == nil {
+			return nil, fmt.Errorf("block: missing attester slashing")
+		}
+		att1, err := slashing.Attestation1.toCore()
+		if err != nil {
+			return nil, err
+		}
+		att2, err := slashing.Attestation2.toCore()
+		if err != nil {
+			return nil, err
+		}
+		ret.AttesterSlashings = append(ret.AttesterSlashings, &core.AttesterSlashing{Attestation_1: att1, Attestation_2: att2})
+	}
+	for _, att := range body.Attestations {
+		coreAtt, err := att.toCore()
+		if err != nil {
+			return nil, err
+		}
+		ret.Attestations = append(ret.Attestations, coreAtt)
+	}
+	for _, deposit := range body.Deposits {
+		if deposit == nil || deposit.Data == nil {
+			return nil, fmt.Errorf("block: missing deposit data")
+		}
+		proof := make([][]byte, len(deposit.Proof))
+		for i, p := range deposit.Proof {
+			proof[i] = p
+		}
+		ret.Deposits = append(ret.Deposits, &core.Deposit{
+			Proof: proof,
+			Data: &core.Deposit_DepositData{
+				PublicKey:             deposit.Data.PubKey,
+				WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
+				Amount:                deposit.Data.Amount,
+				Signature:             deposit.Data.Signature,
+			},
+		})
+	}
+	for _, exit := range body.VoluntaryExits {
+		if exit == nil || exit.Message == nil {
+			return nil, fmt.Errorf("block: missing voluntary exit")
+		}
+		ret.VoluntaryExits = append(ret.VoluntaryExits, &core.SignedVoluntaryExit{
+			Exit:      &core.VoluntaryExit{Epoch: exit.Message.Epoch, ValidatorIndex: exit.Message.ValidatorIndex},
+			Signature: exit.Signature,
+		})
+	}
+	return &core.Block{
+		Slot:       b.Slot,
+		Proposer:   b.ProposerIndex,
+		ParentRoot: b.ParentRoot,
+		StateRoot:  b.StateRoot,
+		Body:       ret,
+	}, nil
+}
+
+func signedBlockHeader(header *core.SignedBlockHeader) *SignedBlockHeader {
+	return &SignedBlockHeader{
+		Message: &BlockHeader{
+			Slot:          header.Header.Slot,
+			ProposerIndex: header.Header.ProposerIndex,
+			ParentRoot:    header.Header.ParentRoot,
+			StateRoot:     header.Header.StateRoot,
+			BodyRoot:      header.Header.BodyRoot,
+		},
+		Signature: header.Signature,
+	}
+}
+
+func (h *SignedBlockHeader) toCore() (*core.SignedBlockHeader, error) {
+	if h == nil || h.Message == nil {
+		return nil, fmt.Errorf("block: missing signed block header")
+	}
+	return &core.SignedBlockHeader{
+		Header: &core.BlockHeader{
+			Slot:          h.Message.Slot,
+			ProposerIndex: h.Message.ProposerIndex,
+			ParentRoot:    h.Message.ParentRoot,
+			StateRoot:     h.Message.StateRoot,
+			BodyRoot:      h.Message.BodyRoot,
+		},
+		Signature: h.Signature,
+	}, nil
+}
+
+func indexedAttestation(att *core.IndexedAttestation) *IndexedAttestation {
+	indices := make([]Uint64, len(att.AttestingIndices))
+	for i, index := range att.AttestingIndices {
+		indices[i] = Uint64(index)
+	}
+	return &IndexedAttestation{AttestingIndices: indices, Data: attestationData(att.Data), Signature: att.Signature}
+}
+
+func (a *IndexedAttestation) toCore() (*core.IndexedAttestation, error) {
+	if a == nil || a.Data == nil {
+		return nil, fmt.Errorf("block: missing indexed attestation")
+	}
+	data, err := a.Data.toCore()
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]uint64, len(a.AttestingIndices))
+	for i, index := range a.AttestingIndices {
+		indices[i] = uint64(index)
+	}
+	return &core.IndexedAttestation{AttestingIndices: indices, Data: data, Signature: a.Signature}, nil
+}
+
+func attestation(att *core.Attestation) *Attestation {
+	return &Attestation{
+		AggregationBits: []byte(att.AggregationBits),
+		Data:            attestationData(att.Data),
+		Signature:       att.Signature,
+	}
+}
+
+func (a *Attestation) toCore() (*core.Attestation, error) {
+	if a == nil || a.Data == nil {
+		return nil, fmt.Errorf("attestation: missing data")
+	}
+	data, err := a.Data.toCore()
+	if err != nil {
+		return nil, err
+	}
+	return &core.Attestation{
+		AggregationBits: []byte(a.AggregationBits),
+		Data:            data,
+		Signature:       a.Signature,
+	}, nil
+}
+
+func attestationData(data *core.AttestationData) *AttestationData {
+	return &AttestationData{
+		Slot:            data.Slot,
+		Index:           data.CommitteeIndex,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source:          &Checkpoint{Epoch: data.Source.Epoch, Root: data.Source.Root},
+		Target:          &Checkpoint{Epoch: data.Target.Epoch, Root: data.Target.Root},
+	}
+}
+
+func (d *AttestationData) toCore() (*core.AttestationData, error) {
+	if d.Source == nil || d.Target == nil {
+		return nil, fmt.Errorf("attestation data: missing checkpoint")
+	}
+	return &core.AttestationData{
+		Slot:            d.Slot,
+		CommitteeIndex:  d.Index,
+		BeaconBlockRoot: d.BeaconBlockRoot,
+		Source:          &core.Checkpoint{Epoch: d.Source.Epoch, Root: d.Source.Root},
+		Target:          &core.Checkpoint{Epoch: d.Target.Epoch, Root: d.Target.Root},
+	}, nil
+}
+
+func aggregateAndProof(msg *core.AggregateAndProof) *AggregateAndProof {
+	return &AggregateAndProof{
+		AggregatorIndex: msg.AggregatorIndex,
+		Aggregate:       attestation(msg.Aggregate),
+		SelectionProof:  msg.SelectionProof,
+	}
+}
+
+func (a *AggregateAndProof) toCore() (*core.AggregateAndProof, error) {
+	if a.Aggregate == nil || a.Aggregate.Data == nil {
+		return nil, fmt.Errorf("aggregate and proof: missing aggregate")
+	}
+	aggregate, err := a.Aggregate.toCore()
+	if err != nil {
+		return nil, err
+	}
+	return &core.AggregateAndProof{
+		AggregatorIndex: a.AggregatorIndex,
+		Aggregate:       aggregate,
+		SelectionProof:  a.SelectionProof,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+/**
+def is_aggregator(state: BeaconState, slot: Slot, index: CommitteeIndex, slot_signature: BLSSignature) -> bool:
+    committee = get_beacon_committee(state, slot, index)
+    modulo = max(1, len(committee) // TARGET_AGGREGATORS_PER_COMMITTEE)
+    return bytes_to_int(hash(slot_signature)[0:8]) % modulo == 0
+ */
+func IsAggregator(state *core.State, slot uint64, index uint64, slotSignature []byte) (bool, error) {
+	committee, err := GetBeaconCommittee(state, slot, index)
+	if err != nil {
+		return false, err
+	}
+	modulo := uint64(len(committee)) / params.ChainConfig.TargetAggregatorsPerCommittee
+	if modulo < 1 {
+		modulo = 1
+	}
+	hash := hashutil.Hash(slotSignature)
+	return binary.LittleEndian.Uint64(hash[:8])%modulo == 0, nil
+}
+
+// VerifySignedAggregateAndProof verifies an aggregate and proof as gossip validation does: the aggregator is a
+// member of the aggregate's committee selected by its selection proof, the selection proof, the aggregator's
+// signature and the aggregate's signature are valid.
+func VerifySignedAggregateAndProof(state *core.State, signed *core.SignedAggregateAndProof) error {
+	if signed.Message == nil || signed.Message.Aggregate == nil || signed.Message.Aggregate.Data == nil {
+		return fmt.Errorf("aggregate and proof: nil message")
+	}
+	msg := signed.Message
+	data := msg.Aggregate.Data
+
+	committee, err := GetBeaconCommittee(state, data.Slot, data.CommitteeIndex)
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	inCommittee := false
+	for _, index := range committee {
+		if index == msg.AggregatorIndex {
+			inCommittee = true
+			break
+		}
+	}
+	if !inCommittee {
+		return fmt.Errorf("aggregate and proof: aggregator %d not in committee", msg.AggregatorIndex)
+	}
+	aggregator := GetValidator(state, msg.AggregatorIndex)
+
+	isAggregator, err := IsAggregator(state, data.Slot, data.CommitteeIndex, msg.SelectionProof)
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	if !isAggregator {
+		return fmt.Errorf("aggregate and proof: validator %d is not an aggregator", msg.AggregatorIndex)
+	}
+
+	// selection proof
+	root, err := SlotSigningRoot(state, data.Slot)
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	if res, err := VerifySignature(root[:], aggregator.PublicKey, msg.SelectionProof); !res || err != nil {
+		return fmt.Errorf("aggregate and proof: selection proof not verified")
+	}
+
+	// aggregator signature
+	domain, err := GetDomain(state, params.ChainConfig.DomainAggregateAndProof, ComputeEpochAtSlot(data.Slot))
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	root, err = ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	if res, err := VerifySignature(root[:], aggregator.PublicKey, signed.Signature); !res || err != nil {
+		return fmt.Errorf("aggregate and proof: signature not verified")
+	}
+
+	// aggregate signature
+	indexed, err := GetIndexedAttestation(state, msg.Aggregate)
+	if err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	if _, err := IsValidIndexedAttestation(state, indexed); err != nil {
+		return fmt.Errorf("aggregate and proof: %s", err.Error())
+	}
+	return nil
+}
+
+// SlotSigningRoot returns the signing root of slot with DOMAIN_SELECTION_PROOF, the ssz hash tree root of a uint64
+// is its little endian bytes padded to 32
+func SlotSigningRoot(state *core.State, slot uint64) ([32]byte, error) {
+	domain, err := GetDomain(state, params.ChainConfig.DomainSelectionProof, ComputeEpochAtSlot(slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return ComputeSigningRoot(bytesutil.ToBytes32(bytesutil.Bytes8(slot)), domain)
+}
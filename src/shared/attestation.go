@@ -24,10 +24,13 @@ import (
  */
 func IsSlashableAttestationData (att1 *core.AttestationData, att2 *core.AttestationData) bool {
 	return (!core.AttestationDataEqual(att1, att2) && att1.Target.Epoch == att2.Target.Epoch) || // double
-		(																						// surround
-			(att1.Source.Epoch < att2.Source.Epoch && att2.Target.Epoch < att1.Target.Epoch) ||
-				(att2.Source.Epoch < att1.Source.Epoch && att1.Target.Epoch < att2.Target.Epoch))
+		IsSurroundVote(att1.Source.Epoch, att1.Target.Epoch, att2.Source.Epoch, att2.Target.Epoch) // surround
+}
 
+// IsSurroundVote returns true if either vote's source and target epochs surround the other's, the surround part of
+// is_slashable_attestation_data for callers that only keep the epochs (like slashing protection).
+func IsSurroundVote(source1 uint64, target1 uint64, source2 uint64, target2 uint64) bool {
+	return (source1 < source2 && target2 < target1) || (source2 < source1 && target1 < target2)
 }
 
 /**
@@ -214,6 +217,9 @@ func GetIndexedAttestation(state *core.State, attestation *core.Attestation) (*c
 	if err != nil {
 		return nil, err
 	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
 
 	return &core.IndexedAttestation{
 		AttestingIndices:     indices,
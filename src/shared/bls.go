@@ -0,0 +1,27 @@
+package shared
+
+import (
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"sync"
+)
+
+var blsOnce sync.Once
+var blsErr error
+
+// InitBLS sets the curve and eth2 hash to curve mode the SDK signs and verifies with, it's called by shared's init
+// and can be called again by packages that use bls without any other shared function.
+func InitBLS() error {
+	blsOnce.Do(func() {
+		if blsErr = bls.Init(bls.BLS12_381); blsErr != nil {
+			return
+		}
+		blsErr = bls.SetETHmode(bls.EthModeDraft07)
+	})
+	return blsErr
+}
+
+func init() {
+	if err := InitBLS(); err != nil {
+		panic(err)
+	}
+}
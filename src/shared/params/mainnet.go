@@ -31,6 +31,12 @@ func mainnetConfig() *core.ChainConfig {
 		MinEpochsToInactivityPenalty: 4, // 4 epochs 25.6 min
 		EpochsPerETH1VotingPeriod: 32, // 32 ~3.4 hours
 		ShardCommitteePeriod: 1 << 8, // 256, ~27H
+		SecondsPerSlot: 12,
+		SafeSlotsToUpdateJustified: 8,
+		IntervalsPerSlot: 3,
+		GenesisDelay: 604800, // 7 days
+		SecondsPerETH1Block: 14,
+		ETH1FollowDistance: 1 << 10, // 1024 blocks, ~4 hours
 
 		// initial values
 
@@ -48,6 +54,8 @@ func mainnetConfig() *core.ChainConfig {
 		HysteresisDownwardMultiplier:   1,
 		HysteresisUpwardMultiplier:     5,
 		ShuffleRoundCount: 				90,
+		ProposerScoreBoost: 			40, // percentage of a committee weight
+		TargetAggregatorsPerCommittee: 16,
 
 		// constants
 		FarFutureEpoch: 		1 << 64-1,
@@ -56,6 +64,7 @@ func mainnetConfig() *core.ChainConfig {
 		GenesisEpoch: 		   	0,
 		BaseRewardsPerEpoch:   	4,
 		DepositContractTreeDepth: 1 << 5, // 32
+		BLSWithdrawalPrefix: []byte{0},
 
 		// state list lengths
 		EpochsPerHistoricalVector: 1 << 16, // ~36 days
@@ -63,7 +63,6 @@ func VerifyBlockSig(state *core.State, signedBlock *core.SignedBlock) error {
 
 
 func SignRandao(data [32]byte, domain []byte, sk []byte) (*bls.Sign, error) {
-	return nil, fmt.Errorf("redo sign randao")
 	root, err := ComputeSigningRoot(data, domain)
 	if err != nil {
 		return nil, err
@@ -28,6 +28,14 @@ func ComputeStartSlotAtEpoch(epoch uint64) uint64 {
 	return epoch * params.ChainConfig.SlotsInEpoch
 }
 
+/**
+def compute_time_at_slot(state: BeaconState, slot: Slot) -> uint64:
+    return uint64(state.genesis_time + slot * SECONDS_PER_SLOT)
+ */
+func ComputeTimeAtSlot(state *core.State, slot uint64) uint64 {
+	return state.GenesisTime + slot * params.ChainConfig.SecondsPerSlot
+}
+
 /**
 def get_current_epoch(state: BeaconState) -> Epoch:
     """
@@ -0,0 +1,53 @@
+package signer
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+)
+
+/**
+def get_aggregate_and_proof(state: BeaconState,
+                            aggregator_index: ValidatorIndex,
+                            aggregate: Attestation,
+                            privkey: int) -> AggregateAndProof:
+    return AggregateAndProof(
+        aggregator_index=aggregator_index,
+        aggregate=aggregate,
+        selection_proof=get_slot_signature(state, aggregate.data.slot, privkey),
+    )
+
+def get_aggregate_and_proof_signature(state: BeaconState,
+                                      aggregate_and_proof: AggregateAndProof,
+                                      privkey: int) -> BLSSignature:
+    aggregate = aggregate_and_proof.aggregate
+    domain = get_domain(state, DOMAIN_AGGREGATE_AND_PROOF, compute_epoch_at_slot(aggregate.data.slot))
+    signing_root = compute_signing_root(aggregate_and_proof, domain)
+    return bls.Sign(privkey, signing_root)
+ */
+// SignedAggregateAndProof returns the aggregator's signed aggregate and proof for aggregate, signed by keySigner
+// with pubKey. The aggregator must be selected by its selection proof.
+func SignedAggregateAndProof(state *core.State, keySigner Signer, pubKey []byte, aggregatorIndex uint64, aggregate *core.Attestation) (*core.SignedAggregateAndProof, error) {
+	selectionProof, err := keySigner.SignSelectionProof(state, aggregate.Data.Slot, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	isAggregator, err := shared.IsAggregator(state, aggregate.Data.Slot, aggregate.Data.CommitteeIndex, selectionProof)
+	if err != nil {
+		return nil, err
+	}
+	if !isAggregator {
+		return nil, fmt.Errorf("validator %d is not an aggregator", aggregatorIndex)
+	}
+
+	msg := &core.AggregateAndProof{
+		AggregatorIndex: aggregatorIndex,
+		Aggregate:       aggregate,
+		SelectionProof:  selectionProof,
+	}
+	sig, err := keySigner.SignAggregateAndProof(state, msg, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &core.SignedAggregateAndProof{Message: msg, Signature: sig}, nil
+}
@@ -0,0 +1,138 @@
+package signer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"sync"
+)
+
+// Signer signs the messages of the validators it holds keys for, identified by their pubkeys. Every method
+// computes the message's domain and signing root itself and returns the serialized BLS signature, so an
+// implementation only decides how the key is used (in process, remote, hardware).
+type Signer interface {
+	// SignBlock signs block with DOMAIN_BEACON_PROPOSER
+	SignBlock(state *core.State, block *core.Block, pubKey []byte) ([]byte, error)
+	// SignRandaoReveal signs epoch with DOMAIN_RANDAO
+	SignRandaoReveal(state *core.State, epoch uint64, pubKey []byte) ([]byte, error)
+	// SignAttestationData signs data with DOMAIN_BEACON_ATTESTER
+	SignAttestationData(state *core.State, data *core.AttestationData, pubKey []byte) ([]byte, error)
+	// SignSelectionProof signs slot with DOMAIN_SELECTION_PROOF, the proof an aggregator is selected with
+	SignSelectionProof(state *core.State, slot uint64, pubKey []byte) ([]byte, error)
+	// SignAggregateAndProof signs msg with DOMAIN_AGGREGATE_AND_PROOF
+	SignAggregateAndProof(state *core.State, msg *core.AggregateAndProof, pubKey []byte) ([]byte, error)
+	// SignVoluntaryExit signs exit with DOMAIN_VOLUNTARY_EXIT
+	SignVoluntaryExit(state *core.State, exit *core.VoluntaryExit, pubKey []byte) ([]byte, error)
+	// SignDeposit signs msg with DOMAIN_DEPOSIT, msg.PublicKey is the signing key
+	SignDeposit(msg *core.DepositMessage) ([]byte, error)
+}
+
+// LocalSigner is a Signer of in process secret keys. The zero LocalSigner holds no keys and is ready to use.
+// A LocalSigner is safe for concurrent use.
+type LocalSigner struct {
+	lock sync.RWMutex
+	keys map[string]*bls.SecretKey
+}
+
+// NewLocalSigner returns a LocalSigner of sks, secret keys in the format the SDK's signing functions take
+func NewLocalSigner(sks ...[]byte) (*LocalSigner, error) {
+	ret := &LocalSigner{keys: make(map[string]*bls.SecretKey)}
+	for _, sk := range sks {
+		if _, err := ret.AddKey(sk); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// AddKey adds the secret key sk and returns its pubkey
+func (s *LocalSigner) AddKey(sk []byte) ([]byte, error) {
+	privKey := &bls.SecretKey{}
+	if err := privKey.SetHexString(hex.EncodeToString(sk)); err != nil {
+		return nil, fmt.Errorf("local signer: invalid secret key: %s", err.Error())
+	}
+	pubKey := privKey.GetPublicKey().Serialize()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.keys == nil {
+		s.keys = make(map[string]*bls.SecretKey)
+	}
+	s.keys[hex.EncodeToString(pubKey)] = privKey
+	return pubKey, nil
+}
+
+// RemoveKey drops the secret key of pubKey
+func (s *LocalSigner) RemoveKey(pubKey []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.keys, hex.EncodeToString(pubKey))
+}
+
+func (s *LocalSigner) SignBlock(state *core.State, block *core.Block, pubKey []byte) ([]byte, error) {
+	root, err := BlockSigningRoot(state, block)
+	if err != nil {
+		return nil, fmt.Errorf("sign block: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignRandaoReveal(state *core.State, epoch uint64, pubKey []byte) ([]byte, error) {
+	root, err := RandaoSigningRoot(state, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("sign randao reveal: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignAttestationData(state *core.State, data *core.AttestationData, pubKey []byte) ([]byte, error) {
+	root, err := AttestationDataSigningRoot(state, data)
+	if err != nil {
+		return nil, fmt.Errorf("sign attestation data: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignSelectionProof(state *core.State, slot uint64, pubKey []byte) ([]byte, error) {
+	root, err := SelectionProofSigningRoot(state, slot)
+	if err != nil {
+		return nil, fmt.Errorf("sign selection proof: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignAggregateAndProof(state *core.State, msg *core.AggregateAndProof, pubKey []byte) ([]byte, error) {
+	root, err := AggregateAndProofSigningRoot(state, msg)
+	if err != nil {
+		return nil, fmt.Errorf("sign aggregate and proof: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignVoluntaryExit(state *core.State, exit *core.VoluntaryExit, pubKey []byte) ([]byte, error) {
+	root, err := VoluntaryExitSigningRoot(state, exit)
+	if err != nil {
+		return nil, fmt.Errorf("sign voluntary exit: %s", err.Error())
+	}
+	return s.sign(root, pubKey)
+}
+
+func (s *LocalSigner) SignDeposit(msg *core.DepositMessage) ([]byte, error) {
+	root, err := DepositSigningRoot(msg)
+	if err != nil {
+		return nil, fmt.Errorf("sign deposit: %s", err.Error())
+	}
+	return s.sign(root, msg.PublicKey)
+}
+
+func (s *LocalSigner) sign(root [32]byte, pubKey []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sk, found := s.keys[hex.EncodeToString(pubKey)]
+	if !found {
+		return nil, fmt.Errorf("local signer: no key for %s", hex.EncodeToString(pubKey))
+	}
+	return sk.SignByte(root[:]).Serialize(), nil
+}
@@ -0,0 +1,119 @@
+package signer
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testState() *core.State {
+	root := make([]byte, 32)
+	root[0] = 1
+	return &core.State{
+		Slot:                  params.ChainConfig.SlotsInEpoch * 2,
+		Fork:                  &core.Fork{PreviousVersion: []byte{0, 0, 0, 0}, CurrentVersion: []byte{0, 0, 0, 1}, Epoch: 1},
+		GenesisValidatorsRoot: root,
+	}
+}
+
+func TestLocalSigner(t *testing.T) {
+	state := testState()
+	s, err := NewLocalSigner([]byte("1"))
+	require.NoError(t, err)
+	pubKey, err := s.AddKey([]byte("2"))
+	require.NoError(t, err)
+
+	data := &core.AttestationData{
+		Slot:            9,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &core.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		Target:          &core.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	}
+	block := &core.Block{Slot: 9, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: &core.BlockBody{
+		RandaoReveal: make([]byte, 96),
+		Eth1Data:     &core.ETH1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+		Graffiti:     make([]byte, 32),
+	}}
+	aggregateAndProof := &core.AggregateAndProof{
+		AggregatorIndex: 1,
+		Aggregate:       &core.Attestation{AggregationBits: []byte{1}, Data: data, Signature: make([]byte, 96)},
+		SelectionProof:  make([]byte, 96),
+	}
+	exit := &core.VoluntaryExit{Epoch: 2, ValidatorIndex: 1}
+	deposit := &core.DepositMessage{PublicKey: pubKey, WithdrawalCredentials: make([]byte, 32), Amount: 32 * 1e9}
+
+	tests := []struct {
+		name string
+		sign func() ([]byte, error)
+		root func() ([32]byte, error)
+	}{
+		{
+			name: "block",
+			sign: func() ([]byte, error) { return s.SignBlock(state, block, pubKey) },
+			root: func() ([32]byte, error) { return BlockSigningRoot(state, block) },
+		},
+		{
+			name: "randao reveal",
+			sign: func() ([]byte, error) { return s.SignRandaoReveal(state, 2, pubKey) },
+			root: func() ([32]byte, error) { return RandaoSigningRoot(state, 2) },
+		},
+		{
+			name: "attestation data",
+			sign: func() ([]byte, error) { return s.SignAttestationData(state, data, pubKey) },
+			root: func() ([32]byte, error) { return AttestationDataSigningRoot(state, data) },
+		},
+		{
+			name: "selection proof",
+			sign: func() ([]byte, error) { return s.SignSelectionProof(state, 9, pubKey) },
+			root: func() ([32]byte, error) { return SelectionProofSigningRoot(state, 9) },
+		},
+		{
+			name: "aggregate and proof",
+			sign: func() ([]byte, error) { return s.SignAggregateAndProof(state, aggregateAndProof, pubKey) },
+			root: func() ([32]byte, error) { return AggregateAndProofSigningRoot(state, aggregateAndProof) },
+		},
+		{
+			name: "voluntary exit",
+			sign: func() ([]byte, error) { return s.SignVoluntaryExit(state, exit, pubKey) },
+			root: func() ([32]byte, error) { return VoluntaryExitSigningRoot(state, exit) },
+		},
+	}
+
+	roots := make(map[[32]byte]bool)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := test.sign()
+			require.NoError(t, err)
+			root, err := test.root()
+			require.NoError(t, err)
+			res, err := shared.VerifySignature(root[:], pubKey, sig)
+			require.NoError(t, err)
+			require.True(t, res)
+
+			// every message type has its own domain
+			require.False(t, roots[root])
+			roots[root] = true
+		})
+	}
+
+	// deposits are signed with the genesis fork version and no genesis validators root
+	sig, err := s.SignDeposit(deposit)
+	require.NoError(t, err)
+	domain, err := shared.ComputeDomain(params.ChainConfig.DomainDeposit, nil, nil)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(deposit, domain)
+	require.NoError(t, err)
+	res, err := shared.VerifySignature(root[:], pubKey, sig)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	// keys it doesn't hold
+	s.RemoveKey(pubKey)
+	_, err = s.SignBlock(state, block, pubKey)
+	require.EqualError(t, err, "local signer: no key for "+hex.EncodeToString(pubKey))
+	_, err = s.SignDeposit(deposit)
+	require.EqualError(t, err, "local signer: no key for "+hex.EncodeToString(pubKey))
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// The signing roots of the messages a validator signs, the domain of each is the one the state transition and
+// gossip validation verify the message with.
+
+// BlockSigningRoot returns the signing root of block with DOMAIN_BEACON_PROPOSER at the block's epoch
+func BlockSigningRoot(state *core.State, block *core.Block) ([32]byte, error) {
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconProposer, shared.ComputeEpochAtSlot(block.Slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return shared.ComputeSigningRoot(block, domain)
+}
+
+// RandaoSigningRoot returns the signing root of epoch with DOMAIN_RANDAO
+func RandaoSigningRoot(state *core.State, epoch uint64) ([32]byte, error) {
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainRandao, epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	// the ssz hash tree root of a uint64 is its little endian bytes padded to 32
+	return shared.ComputeSigningRoot(bytesutil.ToBytes32(bytesutil.Bytes8(epoch)), domain)
+}
+
+// AttestationDataSigningRoot returns the signing root of data with DOMAIN_BEACON_ATTESTER at the target epoch
+func AttestationDataSigningRoot(state *core.State, data *core.AttestationData) ([32]byte, error) {
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconAttester, data.Target.Epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return shared.ComputeSigningRoot(data, domain)
+}
+
+// SelectionProofSigningRoot returns the signing root of slot with DOMAIN_SELECTION_PROOF at the slot's epoch
+func SelectionProofSigningRoot(state *core.State, slot uint64) ([32]byte, error) {
+	return shared.SlotSigningRoot(state, slot)
+}
+
+// AggregateAndProofSigningRoot returns the signing root of msg with DOMAIN_AGGREGATE_AND_PROOF at the aggregate's epoch
+func AggregateAndProofSigningRoot(state *core.State, msg *core.AggregateAndProof) ([32]byte, error) {
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainAggregateAndProof, shared.ComputeEpochAtSlot(msg.Aggregate.Data.Slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return shared.ComputeSigningRoot(msg, domain)
+}
+
+// VoluntaryExitSigningRoot returns the signing root of exit with DOMAIN_VOLUNTARY_EXIT at the exit's epoch
+func VoluntaryExitSigningRoot(state *core.State, exit *core.VoluntaryExit) ([32]byte, error) {
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainVoluntaryExit, exit.Epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return shared.ComputeSigningRoot(exit, domain)
+}
+
+// DepositSigningRoot returns the signing root of msg with DOMAIN_DEPOSIT, deposits are valid across forks so the
+// domain is computed with the genesis fork version and no genesis validators root
+func DepositSigningRoot(msg *core.DepositMessage) ([32]byte, error) {
+	domain, err := shared.ComputeDomain(params.ChainConfig.DomainDeposit, nil, nil)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return shared.ComputeSigningRoot(msg, domain)
+}
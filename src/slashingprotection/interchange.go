@@ -0,0 +1,348 @@
+package slashingprotection
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InterchangeFormatVersion is the EIP-3076 version imported and exported
+const InterchangeFormatVersion = "5"
+
+// Interchange is the EIP-3076 slashing protection interchange format,
+// https://eips.ethereum.org/EIPS/eip-3076
+type Interchange struct {
+	Metadata *InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData   `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData is the signing history of a key, numbers are decimal strings and byte fields 0x prefixed hex
+type InterchangeData struct {
+	PubKey             string                          `json:"pubkey"`
+	SignedBlocks       []*InterchangeSignedBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeSignedAttestation `json:"signed_attestations"`
+}
+
+type InterchangeSignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type InterchangeSignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// Import merges an EIP-3076 interchange (minimal or complete) into the store. The interchange must be for the
+// chain of state (matching genesis_validators_root). Once imported, nothing at or below the highest imported block
+// and attestations is signed (see LowWatermarks), whatever the key signed before.
+func (s *Store) Import(state *core.State, byts []byte) error {
+	interchange := &Interchange{}
+	if err := json.Unmarshal(byts, interchange); err != nil {
+		return fmt.Errorf("import interchange: %s", err.Error())
+	}
+	if interchange.Metadata == nil {
+		return fmt.Errorf("import interchange: missing metadata")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("import interchange: unsupported interchange format version %s", interchange.Metadata.InterchangeFormatVersion)
+	}
+	root, err := decodeHex(interchange.Metadata.GenesisValidatorsRoot, 32)
+	if err != nil {
+		return fmt.Errorf("import interchange: genesis validators root: %s", err.Error())
+	}
+	if !bytes.Equal(root, state.GenesisValidatorsRoot) {
+		return fmt.Errorf("import interchange: genesis validators root %s does not match the state's %s",
+			interchange.Metadata.GenesisValidatorsRoot, encodeHex(state.GenesisValidatorsRoot))
+	}
+
+	// decode everything before touching the store
+	pubKeys := make([][]byte, len(interchange.Data))
+	histories := make([]*History, len(interchange.Data))
+	for i, data := range interchange.Data {
+		if pubKeys[i], err = decodeHex(data.PubKey, 48); err != nil {
+			return fmt.Errorf("import interchange: pubkey %s: %s", data.PubKey, err.Error())
+		}
+		if histories[i], err = data.history(); err != nil {
+			return fmt.Errorf("import interchange: pubkey %s: %s", data.PubKey, err.Error())
+		}
+	}
+
+	for i, pubKey := range pubKeys {
+		if err := s.merge(pubKey, histories[i]); err != nil {
+			return fmt.Errorf("import interchange: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// Export returns the EIP-3076 interchange of pubKeys (all the store's keys if nil) for the chain of state.
+// A minimal interchange holds only each key's highest block and attestation, enough for the importer to never sign
+// anything slashable but not a full record.
+func (s *Store) Export(state *core.State, pubKeys [][]byte, minimal bool) ([]byte, error) {
+	if pubKeys == nil {
+		var err error
+		if pubKeys, err = s.PubKeys(); err != nil {
+			return nil, fmt.Errorf("export interchange: %s", err.Error())
+		}
+	}
+
+	ret := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    encodeHex(state.GenesisValidatorsRoot),
+		},
+		Data: make([]*InterchangeData, 0),
+	}
+	for _, pubKey := range pubKeys {
+		history, err := s.History(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("export interchange: %s", err.Error())
+		}
+		if minimal {
+			history = history.minimal()
+		}
+		ret.Data = append(ret.Data, interchangeData(pubKey, history))
+	}
+
+	byts, err := json.MarshalIndent(ret, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("export interchange: %s", err.Error())
+	}
+	return byts, nil
+}
+
+// PubKeys returns the keys the store has a history for
+func (s *Store) PubKeys() ([][]byte, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	ret := make([][]byte, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		pubKey, err := hex.DecodeString(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil || len(pubKey) != 48 {
+			continue
+		}
+		ret = append(ret, pubKey)
+	}
+	return ret, nil
+}
+
+// merge adds the records of history the key doesn't have yet and raises the key's low watermarks to history's
+// highest slot and epochs
+func (s *Store) merge(pubKey []byte, history *History) error {
+	key, err := s.key(pubKey)
+	if err != nil {
+		return err
+	}
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	blocks := len(key.history.Blocks)
+	attestations := len(key.history.Attestations)
+	watermarks := key.history.LowWatermarks
+	key.history.LowWatermarks = watermarks.raise(history)
+	for _, block := range history.Blocks {
+		if !key.history.hasBlock(block) {
+			key.history.Blocks = append(key.history.Blocks, block)
+		}
+	}
+	for _, att := range history.Attestations {
+		if !key.history.hasAttestation(att) {
+			key.history.Attestations = append(key.history.Attestations, att)
+		}
+	}
+	if err := key.save(); err != nil {
+		key.history.Blocks = key.history.Blocks[:blocks]
+		key.history.Attestations = key.history.Attestations[:attestations]
+		key.history.LowWatermarks = watermarks
+		return err
+	}
+	return nil
+}
+
+// returns new watermarks, the higher of w's (nil for none) and history's highest slot and epochs
+func (w *LowWatermarks) raise(history *History) *LowWatermarks {
+	ret := &LowWatermarks{}
+	if w != nil {
+		ret.Slot, ret.SourceEpoch, ret.TargetEpoch = copyUint64(w.Slot), copyUint64(w.SourceEpoch), copyUint64(w.TargetEpoch)
+	}
+	for _, block := range history.Blocks {
+		ret.Slot = maxUint64(ret.Slot, block.Slot)
+	}
+	for _, att := range history.Attestations {
+		ret.SourceEpoch = maxUint64(ret.SourceEpoch, att.SourceEpoch)
+		ret.TargetEpoch = maxUint64(ret.TargetEpoch, att.TargetEpoch)
+	}
+	if ret.Slot == nil && ret.SourceEpoch == nil && ret.TargetEpoch == nil {
+		return nil
+	}
+	return ret
+}
+
+func maxUint64(current *uint64, v uint64) *uint64 {
+	if current != nil && *current >= v {
+		return current
+	}
+	return &v
+}
+
+func (h *History) hasBlock(block *SignedBlock) bool {
+	for _, b := range h.Blocks {
+		if b.Slot == block.Slot && bytes.Equal(b.SigningRoot, block.SigningRoot) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *History) hasAttestation(att *SignedAttestation) bool {
+	for _, a := range h.Attestations {
+		if a.SourceEpoch == att.SourceEpoch && a.TargetEpoch == att.TargetEpoch && bytes.Equal(a.SigningRoot, att.SigningRoot) {
+			return true
+		}
+	}
+	return false
+}
+
+// minimal keeps the highest block and the attestations with the highest source and target epochs
+func (h *History) minimal() *History {
+	ret := &History{Blocks: []*SignedBlock{}, Attestations: []*SignedAttestation{}}
+	for _, block := range h.Blocks {
+		if len(ret.Blocks) == 0 || block.Slot > ret.Blocks[0].Slot {
+			ret.Blocks = []*SignedBlock{block}
+		}
+	}
+	var maxSource, maxTarget *SignedAttestation
+	for _, att := range h.Attestations {
+		if maxSource == nil || att.SourceEpoch > maxSource.SourceEpoch {
+			maxSource = att
+		}
+		if maxTarget == nil || att.TargetEpoch > maxTarget.TargetEpoch {
+			maxTarget = att
+		}
+	}
+	if maxSource != nil {
+		ret.Attestations = append(ret.Attestations, maxSource)
+		if maxTarget != maxSource {
+			ret.Attestations = append(ret.Attestations, maxTarget)
+		}
+	}
+	return ret
+}
+
+func (d *InterchangeData) history() (*History, error) {
+	ret := &History{Blocks: []*SignedBlock{}, Attestations: []*SignedAttestation{}}
+	for _, block := range d.SignedBlocks {
+		slot, err := strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("block slot: %s", err.Error())
+		}
+		root, err := decodeOptionalRoot(block.SigningRoot)
+		if err != nil {
+			return nil, fmt.Errorf("block signing root: %s", err.Error())
+		}
+		ret.Blocks = append(ret.Blocks, &SignedBlock{Slot: slot, SigningRoot: root})
+	}
+	for _, att := range d.SignedAttestations {
+		source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("attestation source epoch: %s", err.Error())
+		}
+		target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("attestation target epoch: %s", err.Error())
+		}
+		if source > target {
+			return nil, fmt.Errorf("attestation source epoch %d after target epoch %d", source, target)
+		}
+		root, err := decodeOptionalRoot(att.SigningRoot)
+		if err != nil {
+			return nil, fmt.Errorf("attestation signing root: %s", err.Error())
+		}
+		ret.Attestations = append(ret.Attestations, &SignedAttestation{SourceEpoch: source, TargetEpoch: target, SigningRoot: root})
+	}
+	return ret, nil
+}
+
+func interchangeData(pubKey []byte, history *History) *InterchangeData {
+	ret := &InterchangeData{
+		PubKey:             encodeHex(pubKey),
+		SignedBlocks:       make([]*InterchangeSignedBlock, 0),
+		SignedAttestations: make([]*InterchangeSignedAttestation, 0),
+	}
+	sort.SliceStable(history.Blocks, func(i, j int) bool { return history.Blocks[i].Slot < history.Blocks[j].Slot })
+	for _, block := range history.Blocks {
+		ret.SignedBlocks = append(ret.SignedBlocks, &InterchangeSignedBlock{
+			Slot:        strconv.FormatUint(block.Slot, 10),
+			SigningRoot: encodeOptionalRoot(block.SigningRoot),
+		})
+	}
+	sort.SliceStable(history.Attestations, func(i, j int) bool {
+		return history.Attestations[i].TargetEpoch < history.Attestations[j].TargetEpoch
+	})
+	for _, att := range history.Attestations {
+		ret.SignedAttestations = append(ret.SignedAttestations, &InterchangeSignedAttestation{
+			SourceEpoch: strconv.FormatUint(att.SourceEpoch, 10),
+			TargetEpoch: strconv.FormatUint(att.TargetEpoch, 10),
+			SigningRoot: encodeOptionalRoot(att.SigningRoot),
+		})
+	}
+	return ret
+}
+
+func decodeHex(str string, length int) ([]byte, error) {
+	if !strings.HasPrefix(str, "0x") {
+		return nil, fmt.Errorf("must be 0x prefixed")
+	}
+	byts, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(byts) != length {
+		return nil, fmt.Errorf("must be %d bytes", length)
+	}
+	return byts, nil
+}
+
+// a missing (or zero, which EIP-3076 treats the same) signing root is nil
+func decodeOptionalRoot(str string) ([]byte, error) {
+	if str == "" {
+		return nil, nil
+	}
+	ret, err := decodeHex(str, 32)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(ret, make([]byte, 32)) {
+		return nil, nil
+	}
+	return ret, nil
+}
+
+func encodeOptionalRoot(root []byte) string {
+	if root == nil {
+		return ""
+	}
+	return encodeHex(root)
+}
+
+func encodeHex(byts []byte) string {
+	return "0x" + hex.EncodeToString(byts)
+}
@@ -0,0 +1,173 @@
+package slashingprotection
+
+import (
+	"encoding/json"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func chainState() *core.State {
+	root := make([]byte, 32)
+	root[0] = 0x04
+	return &core.State{GenesisValidatorsRoot: root}
+}
+
+// an interchange from another client, https://eips.ethereum.org/EIPS/eip-3076
+const interchangeJSON = `{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x0400000000000000000000000000000000000000000000000000000000000000"
+  },
+  "data": [
+    {
+      "pubkey": "0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+      "signed_blocks": [
+        {
+          "slot": "81952",
+          "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+        },
+        {
+          "slot": "81951"
+        }
+      ],
+      "signed_attestations": [
+        {
+          "source_epoch": "2290",
+          "target_epoch": "3007",
+          "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"
+        },
+        {
+          "source_epoch": "2290",
+          "target_epoch": "3008"
+        }
+      ]
+    }
+  ]
+}`
+
+func TestImport(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	pk := pubKey(1)
+
+	require.NoError(t, store.Import(chainState(), []byte(interchangeJSON)))
+	history, err := store.History(pk)
+	require.NoError(t, err)
+	require.Len(t, history.Blocks, 2)
+	require.Len(t, history.Attestations, 2)
+	require.Nil(t, history.Blocks[1].SigningRoot)
+
+	// importing again doesn't duplicate
+	require.NoError(t, store.Import(chainState(), []byte(interchangeJSON)))
+	history, err = store.History(pk)
+	require.NoError(t, err)
+	require.Len(t, history.Blocks, 2)
+
+	root := [32]byte{0x4f, 0xf6, 0xf7, 0x43, 0xa4, 0x3f, 0x3b, 0x4f, 0x95, 0x35, 0x08, 0x31, 0xae, 0xaf, 0x0a, 0x12,
+		0x2a, 0x1a, 0x39, 0x29, 0x22, 0xc4, 0x5d, 0x80, 0x42, 0x80, 0x28, 0x4a, 0x69, 0xeb, 0x85, 0x0b}
+	require.NoError(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 81952}, root))
+	// no signing root recorded, can't tell a repeat
+	require.Error(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 81951}, [32]byte{1}))
+	require.EqualError(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 81950}, [32]byte{1}), "slashing protection: slot 81950 not higher than the imported slot 81952")
+	require.NoError(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 81953}, [32]byte{1}))
+
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(2289, 3010), [32]byte{1}), "slashing protection: surround vote (source 2289, target 3010) with (source 2290, target 3007)")
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(2290, 3006), [32]byte{1}), "slashing protection: target epoch 3006 not higher than the imported target epoch 3008")
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(3008, 3009), [32]byte{1}))
+}
+
+func TestImportMinimalOverOlderHistory(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	pk := pubKey(1)
+
+	require.NoError(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 10}, [32]byte{1}))
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(1, 2), [32]byte{1}))
+
+	// signed elsewhere since, only the latest messages are known
+	minimal := strings.NewReplacer(
+		`"slot": "81952"`, `"slot": "100"`,
+		`"slot": "81951"`, `"slot": "100"`,
+		`"source_epoch": "2290"`, `"source_epoch": "9"`,
+		`"target_epoch": "3007"`, `"target_epoch": "10"`,
+		`"target_epoch": "3008"`, `"target_epoch": "10"`,
+	).Replace(interchangeJSON)
+	require.NoError(t, store.Import(chainState(), []byte(minimal)))
+
+	require.EqualError(t, store.CheckAndRecordBlock(pk, &core.Block{Slot: 50}, [32]byte{2}), "slashing protection: slot 50 not higher than the imported slot 100")
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(5, 6), [32]byte{2}), "slashing protection: source epoch 5 lower than the imported source epoch 9")
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(9, 10), [32]byte{2}), "slashing protection: double vote for target epoch 10")
+
+	// the watermarks are persisted
+	require.NoError(t, store.Close())
+	reopened, err := NewStore(dir)
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.Error(t, reopened.CheckAndRecordBlock(pk, &core.Block{Slot: 50}, [32]byte{2}))
+	require.Error(t, reopened.CheckAndRecordAttestation(pk, attestationData(9, 9), [32]byte{2}))
+	require.NoError(t, reopened.CheckAndRecordBlock(pk, &core.Block{Slot: 101}, [32]byte{2}))
+	require.NoError(t, reopened.CheckAndRecordAttestation(pk, attestationData(10, 11), [32]byte{2}))
+}
+
+func TestImportErrors(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+
+	otherChain := chainState()
+	otherChain.GenesisValidatorsRoot[0] = 0x05
+	err := store.Import(otherChain, []byte(interchangeJSON))
+	require.EqualError(t, err, "import interchange: genesis validators root 0x0400000000000000000000000000000000000000000000000000000000000000 does not match the state's 0x0500000000000000000000000000000000000000000000000000000000000000")
+
+	err = store.Import(chainState(), []byte(strings.Replace(interchangeJSON, `"interchange_format_version": "5"`, `"interchange_format_version": "4"`, 1)))
+	require.EqualError(t, err, "import interchange: unsupported interchange format version 4")
+
+	err = store.Import(chainState(), []byte(strings.Replace(interchangeJSON, `"slot": "81951"`, `"slot": "a"`, 1)))
+	require.Error(t, err)
+	// nothing imported
+	pubKeys, err := store.PubKeys()
+	require.NoError(t, err)
+	require.Len(t, pubKeys, 0)
+}
+
+func TestExport(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 12}, [32]byte{2}))
+	require.NoError(t, store.CheckAndRecordAttestation(pubKey(1), attestationData(1, 2), [32]byte{1}))
+	require.NoError(t, store.CheckAndRecordAttestation(pubKey(1), attestationData(2, 3), [32]byte{2}))
+	require.NoError(t, store.CheckAndRecordAttestation(pubKey(2), attestationData(0, 1), [32]byte{3}))
+
+	complete, err := store.Export(chainState(), nil, false)
+	require.NoError(t, err)
+	interchange := &Interchange{}
+	require.NoError(t, json.Unmarshal(complete, interchange))
+	require.EqualValues(t, "5", interchange.Metadata.InterchangeFormatVersion)
+	require.Len(t, interchange.Data, 2)
+	require.Len(t, interchange.Data[0].SignedBlocks, 2)
+	require.Len(t, interchange.Data[0].SignedAttestations, 2)
+	require.EqualValues(t, "12", interchange.Data[0].SignedBlocks[1].Slot)
+
+	minimal, err := store.Export(chainState(), [][]byte{pubKey(1)}, true)
+	require.NoError(t, err)
+	interchange = &Interchange{}
+	require.NoError(t, json.Unmarshal(minimal, interchange))
+	require.Len(t, interchange.Data, 1)
+	require.Len(t, interchange.Data[0].SignedBlocks, 1)
+	require.EqualValues(t, "12", interchange.Data[0].SignedBlocks[0].Slot)
+	require.Len(t, interchange.Data[0].SignedAttestations, 1)
+	require.EqualValues(t, "3", interchange.Data[0].SignedAttestations[0].TargetEpoch)
+
+	// a minimal import protects as well as the complete history
+	other, otherDir := tempStore(t)
+	defer os.RemoveAll(otherDir)
+	require.NoError(t, other.Import(chainState(), minimal))
+	require.Error(t, other.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{3}))
+	require.Error(t, other.CheckAndRecordAttestation(pubKey(1), attestationData(1, 2), [32]byte{3}))
+	require.NoError(t, other.CheckAndRecordAttestation(pubKey(1), attestationData(2, 3), [32]byte{2}))
+	require.NoError(t, other.CheckAndRecordAttestation(pubKey(1), attestationData(3, 4), [32]byte{4}))
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package slashingprotection
+
+import (
+	"os"
+	"syscall"
+)
+
+// takes an exclusive, non blocking lock on f, errLocked is returned if it's held by someone else
+func lockExclusive(f *os.File) error {
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err == syscall.EWOULDBLOCK {
+			return errLocked
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build windows
+// +build windows
+
+package slashingprotection
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+// takes an exclusive, non blocking lock on f, errLocked is returned if it's held by someone else
+func lockExclusive(f *os.File) error {
+	overlapped := &windows.Overlapped{}
+	err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, overlapped)
+	if err != nil {
+		if err == windows.ERROR_LOCK_VIOLATION {
+			return errLocked
+		}
+		return err
+	}
+	return nil
+}
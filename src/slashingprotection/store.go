@@ -0,0 +1,313 @@
+package slashingprotection
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+)
+
+// LockFileName is the file a store holds an exclusive lock on while it's open
+const LockFileName = ".lock"
+
+var errLocked = errors.New("locked")
+
+// SignedBlock is a block proposal recorded for a key
+type SignedBlock struct {
+	Slot        uint64 `json:"slot"`
+	SigningRoot []byte `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is an attestation recorded for a key
+type SignedAttestation struct {
+	SourceEpoch uint64 `json:"source_epoch"`
+	TargetEpoch uint64 `json:"target_epoch"`
+	SigningRoot []byte `json:"signing_root,omitempty"`
+}
+
+// History is everything a key signed
+type History struct {
+	Blocks       []*SignedBlock       `json:"blocks"`
+	Attestations []*SignedAttestation `json:"attestations"`
+	// set by importing an interchange
+	LowWatermarks *LowWatermarks `json:"low_watermarks,omitempty"`
+}
+
+// LowWatermarks are the highest slot and epochs of the interchanges imported for a key. An interchange (a minimal one
+// especially) doesn't tell what was signed below them so no block at or below Slot, no attestation with a source
+// below SourceEpoch and no attestation with a target at or below TargetEpoch is signed.
+type LowWatermarks struct {
+	Slot        *uint64 `json:"slot,omitempty"`
+	SourceEpoch *uint64 `json:"source_epoch,omitempty"`
+	TargetEpoch *uint64 `json:"target_epoch,omitempty"`
+}
+
+// Store keeps the signing history of keys, one file per key under its directory, and refuses to sign
+// slashable messages. Every accepted message is persisted before the check returns so a signature is never
+// produced for a message that could be lost in a crash. The whole history of the key is rewritten (and synced)
+// on every accepted message and is never trimmed, so signing gets slower as the history grows.
+// A Store is safe for concurrent use. It holds an exclusive lock on its directory until closed, so a second store
+// (in any process) can't be opened on the same directory.
+type Store struct {
+	dir      string
+	lockFile *os.File
+
+	lock   sync.Mutex
+	keys   map[string]*keyStore
+	closed bool
+}
+
+type keyStore struct {
+	lock    sync.Mutex
+	file    string
+	history *History
+}
+
+// NewStore opens (creating if needed) a store at dir, returns an error if another store has dir open
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	lockFile, err := os.OpenFile(path.Join(dir, LockFileName), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if err := lockExclusive(lockFile); err != nil {
+		lockFile.Close()
+		if err == errLocked {
+			return nil, fmt.Errorf("slashing protection: %s is used by another store", dir)
+		}
+		return nil, fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	return &Store{dir: dir, lockFile: lockFile, keys: make(map[string]*keyStore)}, nil
+}
+
+// Close releases the store's directory, the store can't be used after
+func (s *Store) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	// closing the file releases the lock
+	err := s.lockFile.Close()
+	if err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckAndRecordBlock returns an error if signing block with signingRoot is a double proposal or is lower than the
+// lowest recorded slot, otherwise records it. Signing the same block again is allowed.
+func (s *Store) CheckAndRecordBlock(pubKey []byte, block *core.Block, signingRoot [32]byte) error {
+	key, err := s.key(pubKey)
+	if err != nil {
+		return err
+	}
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	for _, signed := range key.history.Blocks {
+		if signed.Slot != block.Slot {
+			continue
+		}
+		if bytes.Equal(signed.SigningRoot, signingRoot[:]) {
+			return nil
+		}
+		return fmt.Errorf("slashing protection: double proposal at slot %d", block.Slot)
+	}
+	if watermarks := key.history.LowWatermarks; watermarks != nil && watermarks.Slot != nil && block.Slot <= *watermarks.Slot {
+		return fmt.Errorf("slashing protection: slot %d not higher than the imported slot %d", block.Slot, *watermarks.Slot)
+	}
+
+	key.history.Blocks = append(key.history.Blocks, &SignedBlock{Slot: block.Slot, SigningRoot: signingRoot[:]})
+	if err := key.save(); err != nil {
+		key.history.Blocks = key.history.Blocks[:len(key.history.Blocks)-1]
+		return err
+	}
+	return nil
+}
+
+// CheckAndRecordAttestation returns an error if signing data with signingRoot is a double or surround vote
+// (is_slashable_attestation_data against any recorded attestation) or is older than the recorded attestations,
+// otherwise records it. Signing the same attestation again is allowed.
+func (s *Store) CheckAndRecordAttestation(pubKey []byte, data *core.AttestationData, signingRoot [32]byte) error {
+	if data.Source.Epoch > data.Target.Epoch {
+		return fmt.Errorf("slashing protection: source epoch %d after target epoch %d", data.Source.Epoch, data.Target.Epoch)
+	}
+	key, err := s.key(pubKey)
+	if err != nil {
+		return err
+	}
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	for _, signed := range key.history.Attestations {
+		// double vote
+		if signed.TargetEpoch == data.Target.Epoch {
+			if signed.SourceEpoch == data.Source.Epoch && bytes.Equal(signed.SigningRoot, signingRoot[:]) {
+				return nil
+			}
+			return fmt.Errorf("slashing protection: double vote for target epoch %d", data.Target.Epoch)
+		}
+		// surround vote
+		if shared.IsSurroundVote(signed.SourceEpoch, signed.TargetEpoch, data.Source.Epoch, data.Target.Epoch) {
+			return fmt.Errorf("slashing protection: surround vote (source %d, target %d) with (source %d, target %d)",
+				data.Source.Epoch, data.Target.Epoch, signed.SourceEpoch, signed.TargetEpoch)
+		}
+	}
+	if watermarks := key.history.LowWatermarks; watermarks != nil {
+		if watermarks.SourceEpoch != nil && data.Source.Epoch < *watermarks.SourceEpoch {
+			return fmt.Errorf("slashing protection: source epoch %d lower than the imported source epoch %d", data.Source.Epoch, *watermarks.SourceEpoch)
+		}
+		if watermarks.TargetEpoch != nil && data.Target.Epoch <= *watermarks.TargetEpoch {
+			return fmt.Errorf("slashing protection: target epoch %d not higher than the imported target epoch %d", data.Target.Epoch, *watermarks.TargetEpoch)
+		}
+	}
+
+	key.history.Attestations = append(key.history.Attestations, &SignedAttestation{
+		SourceEpoch: data.Source.Epoch,
+		TargetEpoch: data.Target.Epoch,
+		SigningRoot: signingRoot[:],
+	})
+	if err := key.save(); err != nil {
+		key.history.Attestations = key.history.Attestations[:len(key.history.Attestations)-1]
+		return err
+	}
+	return nil
+}
+
+// History returns a copy of the key's signing history
+func (s *Store) History(pubKey []byte) (*History, error) {
+	key, err := s.key(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	return key.history.copy(), nil
+}
+
+func (h *History) copy() *History {
+	ret := &History{
+		Blocks:       make([]*SignedBlock, len(h.Blocks)),
+		Attestations: make([]*SignedAttestation, len(h.Attestations)),
+	}
+	for i, block := range h.Blocks {
+		ret.Blocks[i] = &SignedBlock{Slot: block.Slot, SigningRoot: copyBytes(block.SigningRoot)}
+	}
+	for i, att := range h.Attestations {
+		ret.Attestations[i] = &SignedAttestation{
+			SourceEpoch: att.SourceEpoch,
+			TargetEpoch: att.TargetEpoch,
+			SigningRoot: copyBytes(att.SigningRoot),
+		}
+	}
+	if h.LowWatermarks != nil {
+		ret.LowWatermarks = &LowWatermarks{
+			Slot:        copyUint64(h.LowWatermarks.Slot),
+			SourceEpoch: copyUint64(h.LowWatermarks.SourceEpoch),
+			TargetEpoch: copyUint64(h.LowWatermarks.TargetEpoch),
+		}
+	}
+	return ret
+}
+
+func copyUint64(v *uint64) *uint64 {
+	if v == nil {
+		return nil
+	}
+	ret := *v
+	return &ret
+}
+
+// keeps nil (an unknown signing root) as nil
+func copyBytes(byts []byte) []byte {
+	if byts == nil {
+		return nil
+	}
+	return append([]byte{}, byts...)
+}
+
+// returns the key's store, loading its history from disk the first time
+func (s *Store) key(pubKey []byte) (*keyStore, error) {
+	if len(pubKey) != 48 {
+		return nil, fmt.Errorf("slashing protection: invalid public key")
+	}
+	id := hex.EncodeToString(pubKey)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("slashing protection: store is closed")
+	}
+	if ret, found := s.keys[id]; found {
+		return ret, nil
+	}
+	ret := &keyStore{
+		file:    path.Join(s.dir, id+".json"),
+		history: &History{Blocks: []*SignedBlock{}, Attestations: []*SignedAttestation{}},
+	}
+	byts, err := ioutil.ReadFile(ret.file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if err == nil {
+		if err := json.Unmarshal(byts, ret.history); err != nil {
+			return nil, fmt.Errorf("slashing protection: corrupted history for %s: %s", id, err.Error())
+		}
+	}
+	s.keys[id] = ret
+	return ret, nil
+}
+
+// save writes the history to a temporary file and renames it over the previous one, the file is always either the
+// previous or the new history.
+func (k *keyStore) save() error {
+	byts, err := json.Marshal(k.history)
+	if err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+
+	tmp := k.file + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if _, err := f.Write(byts); err != nil {
+		f.Close()
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	if err := os.Rename(tmp, k.file); err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+
+	// persist the rename
+	dir, err := os.Open(path.Dir(k.file))
+	if err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("slashing protection: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package slashingprotection
+
+import (
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+)
+
+func pubKey(b byte) []byte {
+	ret := make([]byte, 48)
+	ret[0] = b
+	return ret
+}
+
+func attestationData(source uint64, target uint64) *core.AttestationData {
+	return &core.AttestationData{
+		Source: &core.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+		Target: &core.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+	}
+}
+
+func tempStore(t *testing.T) (*Store, string) {
+	dir, err := ioutil.TempDir("", "slashing_protection")
+	require.NoError(t, err)
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	return store, dir
+}
+
+func TestBlocks(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+	// same block again
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+	require.EqualError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{2}), "slashing protection: double proposal at slot 10")
+	// other keys are not affected
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(2), &core.Block{Slot: 10}, [32]byte{2}))
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 11}, [32]byte{3}))
+
+	history, err := store.History(pubKey(1))
+	require.NoError(t, err)
+	require.Len(t, history.Blocks, 2)
+	// the history is a copy
+	history.Blocks[0].Slot = 100
+	history.Blocks[0].SigningRoot[0] = 0xff
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+
+	_, err = store.History([]byte{1})
+	require.EqualError(t, err, "slashing protection: invalid public key")
+}
+
+func TestAttestations(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	pk := pubKey(1)
+
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(2, 5), [32]byte{1}))
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(2, 5), [32]byte{1}))
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(2, 5), [32]byte{2}), "slashing protection: double vote for target epoch 5")
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(3, 5), [32]byte{1}), "slashing protection: double vote for target epoch 5")
+
+	// surrounded by the recorded attestation
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(3, 4), [32]byte{3}), "slashing protection: surround vote (source 3, target 4) with (source 2, target 5)")
+	// surrounding the recorded attestation
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(1, 6), [32]byte{3}), "slashing protection: surround vote (source 1, target 6) with (source 2, target 5)")
+
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(2, 6), [32]byte{4}))
+	require.NoError(t, store.CheckAndRecordAttestation(pk, attestationData(5, 7), [32]byte{5}))
+	require.EqualError(t, store.CheckAndRecordAttestation(pk, attestationData(8, 7), [32]byte{6}), "slashing protection: source epoch 8 after target epoch 7")
+}
+
+func TestPersistence(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+	require.NoError(t, store.CheckAndRecordAttestation(pubKey(1), attestationData(2, 5), [32]byte{1}))
+
+	// only one store has the directory open
+	_, err := NewStore(dir)
+	require.EqualError(t, err, "slashing protection: "+dir+" is used by another store")
+	require.NoError(t, store.Close())
+	require.NoError(t, store.Close())
+	require.EqualError(t, store.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 11}, [32]byte{1}), "slashing protection: store is closed")
+	reopened, err := NewStore(dir)
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.Error(t, reopened.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{2}))
+	require.Error(t, reopened.CheckAndRecordAttestation(pubKey(1), attestationData(3, 4), [32]byte{2}))
+	require.NoError(t, reopened.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 10}, [32]byte{1}))
+
+	// a failed write doesn't record the message
+	require.NoError(t, os.Chmod(dir, 0500))
+	defer os.Chmod(dir, 0700)
+	if os.Getuid() != 0 {
+		require.Error(t, reopened.CheckAndRecordBlock(pubKey(1), &core.Block{Slot: 12}, [32]byte{1}))
+		history, err := reopened.History(pubKey(1))
+		require.NoError(t, err)
+		require.Len(t, history.Blocks, 1)
+	}
+}
+
+func TestConcurrentSigners(t *testing.T) {
+	store, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	accepted := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := store.CheckAndRecordAttestation(pubKey(1), attestationData(1, 2), [32]byte{byte(i)}); err == nil {
+				lock.Lock()
+				accepted++
+				lock.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, accepted)
+}
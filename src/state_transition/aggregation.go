@@ -0,0 +1,65 @@
+package state_transition
+
+import (
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// AggregateAttestations merges attestations with identical data and non overlapping aggregation bits into a
+// single attestation whose signature is the aggregate of their signatures. The result is verified with
+// is_valid_indexed_attestation against state.
+func AggregateAttestations(state *core.State, attestations ...*core.Attestation) (*core.Attestation, error) {
+	if len(attestations) == 0 {
+		return nil, fmt.Errorf("aggregate attestations: no attestations")
+	}
+
+	first := attestations[0]
+	dataRoot, err := first.Data.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+	}
+	bits := first.AggregationBits.Or(first.AggregationBits) // copy
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(first.Signature); err != nil {
+		return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+	}
+
+	for _, att := range attestations[1:] {
+		root, err := att.Data.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+		}
+		if root != dataRoot {
+			return nil, fmt.Errorf("aggregate attestations: attestation data not equal")
+		}
+		if att.AggregationBits.Len() != bits.Len() {
+			return nil, fmt.Errorf("aggregate attestations: aggregation bits length not equal")
+		}
+		if bits.Overlaps(att.AggregationBits) {
+			return nil, fmt.Errorf("aggregate attestations: aggregation bits overlap")
+		}
+
+		attSig := &bls.Sign{}
+		if err := attSig.Deserialize(att.Signature); err != nil {
+			return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+		}
+		sig.Add(attSig)
+		bits = bits.Or(att.AggregationBits)
+	}
+
+	ret := &core.Attestation{
+		AggregationBits: bits,
+		Data:            first.Data,
+		Signature:       sig.Serialize(),
+	}
+	indexed, err := shared.GetIndexedAttestation(state, ret)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+	}
+	if err := isValidIndexedAttestation(state, indexed); err != nil {
+		return nil, fmt.Errorf("aggregate attestations: %s", err.Error())
+	}
+	return ret, nil
+}
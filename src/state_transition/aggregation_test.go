@@ -0,0 +1,137 @@
+package state_transition
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// a committee 0 attestation for the state's previous slot signed by the committee members at positions
+func committeeAttestation(t *testing.T, state *core.State, headRoot byte, positions ...int) *core.Attestation {
+	slot := state.Slot - 1
+	epoch := shared.ComputeEpochAtSlot(slot)
+	data := &core.AttestationData{
+		Slot:            slot,
+		CommitteeIndex:  0,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          state.CurrentJustifiedCheckpoint,
+		Target:          &core.Checkpoint{Epoch: epoch, Root: make([]byte, 32)},
+	}
+	data.BeaconBlockRoot[0] = headRoot
+	domain, err := shared.GetDomain(state, params.ChainConfig.DomainBeaconAttester, epoch)
+	require.NoError(t, err)
+	root, err := shared.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	committee, err := shared.GetBeaconCommittee(state, slot, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	sig := &bls.Sign{}
+	for i, position := range positions {
+		bits.SetBitAt(uint64(position), true)
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.SetHexString(hex.EncodeToString([]byte(fmt.Sprintf("%d", committee[position])))))
+		if i == 0 {
+			sig = sk.SignByte(root[:])
+		} else {
+			sig.Add(sk.SignByte(root[:]))
+		}
+	}
+	return &core.Attestation{AggregationBits: bits, Data: data, Signature: sig.Serialize()}
+}
+
+func TestAggregateAttestations(t *testing.T) {
+	ctx := NewStateTestContext(params.ChainConfig, nil, 0)
+	ctx.PopulateGenesisValidator(params.ChainConfig.MinGenesisActiveValidatorCount)
+	state := ctx.State
+	require.NoError(t, NewStateTransition().ProcessSlots(state, 2))
+
+	a := committeeAttestation(t, state, 1, 0)
+	b := committeeAttestation(t, state, 1, 1, 2)
+	aggregated, err := AggregateAttestations(state, a, b)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, aggregated.AggregationBits.Count())
+	require.EqualValues(t, []int{0, 1, 2}, aggregated.AggregationBits.BitIndices())
+	// the inputs are not modified
+	require.EqualValues(t, 1, a.AggregationBits.Count())
+
+	// a whole committee in one attestation
+	require.NoError(t, ProcessBlockAttestations(shared.CopyState(state), []*core.Attestation{aggregated}))
+
+	single, err := AggregateAttestations(state, a)
+	require.NoError(t, err)
+	require.EqualValues(t, a, single)
+
+	_, err = AggregateAttestations(state)
+	require.EqualError(t, err, "aggregate attestations: no attestations")
+	_, err = AggregateAttestations(state, a, committeeAttestation(t, state, 1, 0, 1))
+	require.EqualError(t, err, "aggregate attestations: aggregation bits overlap")
+	_, err = AggregateAttestations(state, a, committeeAttestation(t, state, 2, 1))
+	require.EqualError(t, err, "aggregate attestations: attestation data not equal")
+
+	invalid := committeeAttestation(t, state, 1, 1)
+	invalid.Signature = a.Signature
+	_, err = AggregateAttestations(state, a, invalid)
+	require.EqualError(t, err, "aggregate attestations: attestation signature not vrified")
+}
+
+func TestSignedAggregateAndProof(t *testing.T) {
+	ctx := NewStateTestContext(params.ChainConfig, nil, 0)
+	ctx.PopulateGenesisValidator(params.ChainConfig.MinGenesisActiveValidatorCount)
+	state := ctx.State
+	require.NoError(t, NewStateTransition().ProcessSlots(state, 2))
+
+	aggregate := committeeAttestation(t, state, 1, 0, 1, 2)
+	committee, err := shared.GetBeaconCommittee(state, aggregate.Data.Slot, 0)
+	require.NoError(t, err)
+	pubKey := func(index uint64) []byte { return state.Validators[index].PublicKey }
+
+	// committees smaller than TARGET_AGGREGATORS_PER_COMMITTEE have all members aggregate
+	selectionProof, err := ctx.Signer.SignSelectionProof(state, aggregate.Data.Slot, pubKey(committee[3]))
+	require.NoError(t, err)
+	isAggregator, err := shared.IsAggregator(state, aggregate.Data.Slot, 0, selectionProof)
+	require.NoError(t, err)
+	require.True(t, isAggregator)
+
+	signed, err := signer.SignedAggregateAndProof(state, ctx.Signer, pubKey(committee[3]), committee[3], aggregate)
+	require.NoError(t, err)
+	require.NoError(t, shared.VerifySignedAggregateAndProof(state, signed))
+
+	byts, err := signed.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := &core.SignedAggregateAndProof{}
+	require.NoError(t, decoded.UnmarshalSSZ(byts))
+	require.NoError(t, shared.VerifySignedAggregateAndProof(state, decoded))
+
+	// signed by another validator
+	wrongKey, err := signer.SignedAggregateAndProof(state, ctx.Signer, pubKey(committee[2]), committee[3], aggregate)
+	require.NoError(t, err)
+	require.EqualError(t, shared.VerifySignedAggregateAndProof(state, wrongKey), "aggregate and proof: selection proof not verified")
+	wrongKey.Message.SelectionProof = signed.Message.SelectionProof
+	require.EqualError(t, shared.VerifySignedAggregateAndProof(state, wrongKey), "aggregate and proof: signature not verified")
+
+	// not a committee member
+	outsider := uint64(0)
+	for ; outsider < uint64(len(state.Validators)); outsider++ {
+		if outsider != committee[0] && outsider != committee[1] && outsider != committee[2] && outsider != committee[3] {
+			break
+		}
+	}
+	notMember, err := signer.SignedAggregateAndProof(state, ctx.Signer, pubKey(outsider), outsider, aggregate)
+	require.NoError(t, err)
+	require.EqualError(t, shared.VerifySignedAggregateAndProof(state, notMember), fmt.Sprintf("aggregate and proof: aggregator %d not in committee", outsider))
+
+	// an invalid aggregate
+	invalid := committeeAttestation(t, state, 1, 0, 1)
+	invalid.Signature = committeeAttestation(t, state, 1, 0).Signature
+	invalidAggregate, err := signer.SignedAggregateAndProof(state, ctx.Signer, pubKey(committee[3]), committee[3], invalid)
+	require.NoError(t, err)
+	require.EqualError(t, shared.VerifySignedAggregateAndProof(state, invalidAggregate), "aggregate and proof: indexed attestation signature not vrified")
+}
@@ -18,7 +18,7 @@ func ProcessBlockAttestations(state *core.State, attestations []*core.Attestatio
 }
 
 func processAttestation(state *core.State, attestation *core.Attestation) error {
-	if err := processAttestationNoSigVerify(state, attestation); err != nil {
+	if err := ProcessAttestationNoSigVerify(state, attestation); err != nil {
 		return err
 	}
 
@@ -61,7 +61,7 @@ func processAttestation(state *core.State, attestation *core.Attestation) error
 //    else:
 //        assert data.source == state.previous_justified_checkpoint
 //        state.previous_epoch_attestations.append(pending_attestation)
-func processAttestationNoSigVerify(state *core.State, attestation *core.Attestation) error {
+func ProcessAttestationNoSigVerify(state *core.State, attestation *core.Attestation) error {
 	if err := validateAttestationData(state, attestation.Data); err != nil {
 		return err
 	}
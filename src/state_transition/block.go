@@ -7,6 +7,7 @@ import (
 	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
 	"github.com/prysmaticlabs/go-ssz"
 )
 
@@ -17,7 +18,7 @@ func (st *StateTransition) ProcessBlock(state *core.State, block *core.Block) er
 	if err := processRANDAO(state, block); err != nil {
 		return fmt.Errorf("ProcessBlock: %s", err.Error())
 	}
-	if err := processEth1Data(state, block.Body); err != nil {
+	if err := ProcessEth1Data(state, block.Body); err != nil {
 		return fmt.Errorf("ProcessBlock: %s", err.Error())
 	}
 	if err := processOperations(state, block.Body); err != nil {
@@ -26,17 +27,62 @@ func (st *StateTransition) ProcessBlock(state *core.State, block *core.Block) er
 	return nil
 }
 
-func (st *StateTransition) processBlockForStateRoot(state *core.State, signedBlock *core.SignedBlock) error {
-	if err := ProcessBlockHeader(state, signedBlock.Block); err != nil {
+// BuildBlock advances a copy of state to slot, applies body to it without verifying signatures and returns
+// the block with the post state root, signed by the slot's proposer with keySigner. The body's randao reveal must be set.
+func (st *StateTransition) BuildBlock(state *core.State, slot uint64, body *core.BlockBody, keySigner signer.Signer) (*core.SignedBlock, error) {
+	newState := shared.CopyState(state)
+	if err := st.ProcessSlots(newState, slot); err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+
+	proposer, err := shared.GetBlockProposerIndex(newState)
+	if err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+	parentRoot, err := newState.LatestBlockHeader.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+	block := &core.Block{
+		Slot:       slot,
+		Proposer:   proposer,
+		ParentRoot: parentRoot[:],
+		StateRoot:  params.ChainConfig.ZeroHash,
+		Body:       body,
+	}
+
+	// compute state root
+	if err := st.processBlockForStateRoot(newState, block); err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+	stateRoot, err := newState.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+	block.StateRoot = stateRoot[:]
+
+	// sign
+	sig, err := keySigner.SignBlock(newState, block, shared.GetValidator(newState, proposer).PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("BuildBlock: %s", err.Error())
+	}
+	return &core.SignedBlock{
+		Block:     block,
+		Signature: sig,
+	}, nil
+}
+
+func (st *StateTransition) processBlockForStateRoot(state *core.State, block *core.Block) error {
+	if err := ProcessBlockHeader(state, block); err != nil {
 		return fmt.Errorf("processBlockForStateRoot: %s", err.Error())
 	}
-	if err := processRANDAONoVerify(state, signedBlock.Block); err != nil {
+	if err := processRANDAONoVerify(state, block); err != nil {
 		return fmt.Errorf("processBlockForStateRoot: %s", err.Error())
 	}
-	if err := processEth1Data(state, signedBlock.Block.Body); err != nil {
+	if err := ProcessEth1Data(state, block.Body); err != nil {
 		return fmt.Errorf("processBlockForStateRoot: %s", err.Error())
 	}
-	if err := processOperationsNoVerify(state, signedBlock.Block.Body); err != nil {
+	if err := processOperationsNoVerify(state, block.Body); err != nil {
 		return fmt.Errorf("processBlockForStateRoot: %s", err.Error())
 	}
 	return nil
@@ -126,7 +172,7 @@ def process_eth1_data(state: BeaconState, body: BeaconBlockBody) -> None:
     if state.eth1_data_votes.count(body.eth1_data) * 2 > EPOCHS_PER_ETH1_VOTING_PERIOD * SLOTS_PER_EPOCH:
         state.eth1_data = body.eth1_data
  */
-func processEth1Data(state *core.State, body *core.BlockBody) error {
+func ProcessEth1Data(state *core.State, body *core.BlockBody) error {
 	state.Eth1DataVotes = append(state.Eth1DataVotes, body.Eth1Data)
 
 	// count support
@@ -188,7 +234,7 @@ func processOperationsNoVerify(state *core.State, body *core.BlockBody) error {
 		return err
 	}
 	for _, att := range body.Attestations {
-		if err := processAttestationNoSigVerify(state, att); err != nil {
+		if err := ProcessAttestationNoSigVerify(state, att); err != nil {
 			return err
 		}
 	}
@@ -203,13 +249,5 @@ func processOperationsNoVerify(state *core.State, body *core.BlockBody) error {
 
 // AreEth1DataEqual checks equality between two eth1 data objects.
 func AreEth1DataEqual(a, b *core.ETH1Data) bool {
-	if a == nil && b == nil {
-		return true
-	}
-	if a == nil || b == nil {
-		return false
-	}
-	return a.DepositCount == b.DepositCount &&
-		bytes.Equal(a.BlockHash, b.BlockHash) &&
-		bytes.Equal(a.DepositRoot, b.DepositRoot)
+	return core.ETH1DataEqual(a, b)
 }
@@ -0,0 +1,89 @@
+package state_transition
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// signs blocks with the key of another validator
+type wrongKeySigner struct {
+	*signer.LocalSigner
+	pubKey []byte
+}
+
+func (s *wrongKeySigner) SignBlock(state *core.State, block *core.Block, _ []byte) ([]byte, error) {
+	return s.LocalSigner.SignBlock(state, block, s.pubKey)
+}
+
+// returns the proposer of slot and a body with its randao reveal
+func proposerBody(t *testing.T, ctx *StateTestContext, state *core.State, slot uint64) (uint64, *core.BlockBody) {
+	st := NewStateTransition()
+	stateCopy := shared.CopyState(state)
+	require.NoError(t, st.ProcessSlots(stateCopy, slot))
+	proposer, err := shared.GetBlockProposerIndex(stateCopy)
+	require.NoError(t, err)
+
+	randaoReveal, err := ctx.Signer.SignRandaoReveal(stateCopy, shared.GetCurrentEpoch(stateCopy), shared.GetValidator(stateCopy, proposer).PublicKey)
+	require.NoError(t, err)
+
+	return proposer, &core.BlockBody{
+		RandaoReveal: randaoReveal,
+		Attestations: []*core.Attestation{},
+		Eth1Data:     state.Eth1Data,
+		Graffiti:     make([]byte, 32),
+	}
+}
+
+func TestBuildBlock(t *testing.T) {
+	ctx := NewStateTestContext(params.ChainConfig, nil, 0)
+	ctx.PopulateGenesisValidator(params.ChainConfig.MinGenesisActiveValidatorCount)
+	st := NewStateTransition()
+
+	state := ctx.State
+	// a block on the next slot and one after skipped slots (crossing an epoch)
+	for _, slot := range []uint64{1, params.ChainConfig.SlotsInEpoch + 2} {
+		proposer, body := proposerBody(t, ctx, state, slot)
+		preRoot, err := state.HashTreeRoot()
+		require.NoError(t, err)
+
+		signedBlock, err := st.BuildBlock(state, slot, body, ctx.Signer)
+		require.NoError(t, err)
+		require.EqualValues(t, slot, signedBlock.Block.Slot)
+		require.EqualValues(t, proposer, signedBlock.Block.Proposer)
+
+		// the pre state is not modified
+		postRoot, err := state.HashTreeRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, preRoot, postRoot)
+
+		newState, err := st.ExecuteStateTransition(state, signedBlock, true)
+		require.NoError(t, err)
+		stateRoot, err := newState.HashTreeRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, stateRoot[:], signedBlock.Block.StateRoot)
+		state = newState
+	}
+
+	// signed by the wrong key
+	proposer, body := proposerBody(t, ctx, state, state.Slot+1)
+	wrongKey := &wrongKeySigner{LocalSigner: ctx.Signer, pubKey: state.Validators[(proposer+1)%uint64(len(state.Validators))].PublicKey}
+	signedBlock, err := st.BuildBlock(state, state.Slot+1, body, wrongKey)
+	require.NoError(t, err)
+	_, err = st.ExecuteStateTransition(state, signedBlock, true)
+	require.EqualError(t, err, "ExecuteStateTransition: block sig not verified")
+
+	// a slot that was already processed
+	_, err = st.BuildBlock(state, state.Slot, body, ctx.Signer)
+	require.Error(t, err)
+
+	// the signer doesn't hold the proposer's key
+	emptySigner, err := signer.NewLocalSigner()
+	require.NoError(t, err)
+	_, err = st.BuildBlock(state, state.Slot+1, body, emptySigner)
+	require.EqualError(t, err, "BuildBlock: local signer: no key for "+hex.EncodeToString(shared.GetValidator(state, proposer).PublicKey))
+}
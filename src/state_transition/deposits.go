@@ -21,7 +21,7 @@ func ProcessDeposits(state *core.State, deposits []*core.Deposit) error {
 	}
 
 	for _, deposit := range deposits {
-		if err := processDeposit(state, deposit); err != nil {
+		if err := ProcessDeposit(state, deposit); err != nil {
 			return err
 		}
 	}
@@ -65,7 +65,7 @@ def process_deposit(state: BeaconState, deposit: Deposit) -> None:
         index = ValidatorIndex(validator_pubkeys.index(pubkey))
         increase_balance(state, index, amount)
  */
-func processDeposit(state *core.State, deposit *core.Deposit) error {
+func ProcessDeposit(state *core.State, deposit *core.Deposit) error {
 	// Verify the Merkle branch
 	if err := verifyDeposit(state, deposit); err != nil {
 		return fmt.Errorf("process deposit: %s", err.Error())
@@ -119,20 +119,28 @@ func verifyDeposit(state *core.State, deposit *core.Deposit) error {
 		return fmt.Errorf("received nil eth1data in the beacon state")
 	}
 
-	receiptRoot := state.Eth1Data.DepositRoot
+	return VerifyDepositMerkleBranch(state.Eth1Data.DepositRoot, state.Eth1DepositIndex, deposit)
+}
+
+// VerifyDepositMerkleBranch verifies the deposit's proof against depositRoot at index
+func VerifyDepositMerkleBranch(depositRoot []byte, index uint64, deposit *core.Deposit) error {
+	if deposit == nil || deposit.Data == nil {
+		return fmt.Errorf("received nil deposit or nil deposit data")
+	}
+
 	leaf, err := ssz.HashTreeRoot(deposit.Data)
 	if err != nil {
 		return fmt.Errorf("could not tree hash deposit data")
 	}
 
 	if ok := trieutil.VerifyMerkleBranch(
-			receiptRoot,
+			depositRoot,
 			leaf[:],
-			int(state.Eth1DepositIndex),
+			int(index),
 			deposit.Proof,
 			params.ChainConfig.DepositContractTreeDepth,
 		); !ok {
-		return fmt.Errorf("deposit merkle branch of deposit root did not verify for root: %#x", receiptRoot)
+		return fmt.Errorf("deposit merkle branch of deposit root did not verify for root: %#x", depositRoot)
 	}
 	return nil
 }
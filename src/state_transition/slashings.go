@@ -10,7 +10,7 @@ import (
 
 func ProcessProposerSlashings(state *core.State, slashings []*core.ProposerSlashing) error {
 	for _, s := range slashings {
-		if err := processProposerSlashing(state, s); err != nil {
+		if err := ProcessProposerSlashing(state, s); err != nil {
 			return err
 		}
 	}
@@ -39,7 +39,7 @@ def process_proposer_slashing(state: BeaconState, proposer_slashing: ProposerSla
 
     slash_validator(state, header_1.proposer_index)
 */
-func processProposerSlashing(state *core.State, slashing *core.ProposerSlashing) error {
+func ProcessProposerSlashing(state *core.State, slashing *core.ProposerSlashing) error {
 	header1 := slashing.Header_1.Header
 	header2 := slashing.Header_2.Header
 
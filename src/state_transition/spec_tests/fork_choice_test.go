@@ -0,0 +1,189 @@
+package spec_tests
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/forkchoice"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+type ForkChoiceCheckpoint struct {
+	Epoch uint64 `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type ForkChoiceHead struct {
+	Slot uint64 `json:"slot"`
+	Root string `json:"root"`
+}
+
+type ForkChoiceChecks struct {
+	Time                    *uint64               `json:"time"`
+	GenesisTime             *uint64               `json:"genesis_time"`
+	Head                    *ForkChoiceHead       `json:"head"`
+	JustifiedCheckpoint     *ForkChoiceCheckpoint `json:"justified_checkpoint"`
+	BestJustifiedCheckpoint *ForkChoiceCheckpoint `json:"best_justified_checkpoint"`
+	FinalizedCheckpoint     *ForkChoiceCheckpoint `json:"finalized_checkpoint"`
+	ProposerBoostRoot       *string               `json:"proposer_boost_root"`
+}
+
+// a single entry of steps.yaml, only one of the fields is set (valid comes along block/ attestation steps)
+type ForkChoiceStep struct {
+	Tick             *uint64           `json:"tick"`
+	Block            *string           `json:"block"`
+	Attestation      *string           `json:"attestation"`
+	AttesterSlashing *string           `json:"attester_slashing"`
+	Valid            *bool             `json:"valid"`
+	Checks           *ForkChoiceChecks `json:"checks"`
+}
+
+// testdata holds a few hand checked cases in the spec tests layout so the runner always executes something,
+// the downloaded vectors (make spec_test_fork_choice) are run along when present
+var forkChoiceTestsFolders = []string{"testdata/tests", rootSpecTestsFolder}
+
+func TestSpecForkChoiceMainnet(t *testing.T) {
+	params.UseMainnetConfig()
+	base, err := os.Getwd()
+	require.NoError(t, err)
+
+	for _, folder := range forkChoiceTestsFolders {
+		root := path.Join(base, folder, "mainnet/phase0/fork_choice")
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			t.Logf("no fork choice spec tests in %s", root)
+			continue
+		}
+		handlers, err := ioutil.ReadDir(root)
+		require.NoError(t, err)
+		for _, handler := range handlers { // get_head, on_block, ...
+			t.Run(handler.Name(), func(tt *testing.T) {
+				casesPath := path.Join(root, handler.Name(), "pyspec_tests")
+				cases, err := ioutil.ReadDir(casesPath)
+				require.NoError(tt, err)
+				for _, c := range cases {
+					tt.Run(c.Name(), func(ttt *testing.T) {
+						caseDir := path.Join(casesPath, c.Name())
+						ttt.Run("spec", func(tttt *testing.T) {
+							runForkChoiceCase(tttt, caseDir, forkchoice.NewStore)
+						})
+						ttt.Run("proto_array", func(tttt *testing.T) {
+							runForkChoiceCase(tttt, caseDir, forkchoice.NewProtoArrayStore)
+						})
+					})
+				}
+			})
+		}
+	}
+}
+
+func runForkChoiceCase(t *testing.T, dir string, newStore func(*core.State) (*forkchoice.Store, error)) {
+	anchorState := loadForkChoiceObject(t, dir, "anchor_state", "BeaconState").(*core.State)
+	store, err := newStore(anchorState)
+	require.NoError(t, err)
+
+	// the store derives its anchor from the state's latest block header, it must be the anchor block
+	if _, err := os.Stat(path.Join(dir, "anchor_block.ssz_snappy")); err == nil {
+		anchorBlock := loadForkChoiceObject(t, dir, "anchor_block", "BeaconBlock").(*core.Block)
+		anchorRoot, err := anchorBlock.HashTreeRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, anchorRoot[:], store.JustifiedCheckpoint().Root)
+	}
+
+	stepsByts, err := ioutil.ReadFile(path.Join(dir, "steps.yaml"))
+	require.NoError(t, err)
+	var steps []*ForkChoiceStep
+	require.NoError(t, testutil.UnmarshalYaml(stepsByts, &steps))
+
+	for i, step := range steps {
+		valid := step.Valid == nil || *step.Valid
+		switch {
+		case step.Tick != nil:
+			store.OnTick(*step.Tick)
+		case step.Block != nil:
+			block := loadForkChoiceObject(t, dir, *step.Block, "SignedBeaconBlock").(*core.SignedBlock)
+			requireValidStep(t, i, valid, store.OnBlock(block))
+		case step.Attestation != nil:
+			attestation := loadForkChoiceObject(t, dir, *step.Attestation, "Attestation").(*core.Attestation)
+			requireValidStep(t, i, valid, store.OnAttestation(attestation))
+		case step.AttesterSlashing != nil:
+			slashing := loadForkChoiceObject(t, dir, *step.AttesterSlashing, "AttesterSlashing").(*core.AttesterSlashing)
+			requireValidStep(t, i, valid, store.OnAttesterSlashing(slashing))
+		case step.Checks != nil:
+			runForkChoiceChecks(t, i, store, step.Checks)
+		default:
+			t.Fatalf("step %d: unknown step", i)
+		}
+	}
+}
+
+func requireValidStep(t *testing.T, index int, valid bool, err error) {
+	if valid {
+		require.NoError(t, err, "step %d", index)
+	} else {
+		require.Error(t, err, "step %d", index)
+	}
+}
+
+func runForkChoiceChecks(t *testing.T, index int, store *forkchoice.Store, checks *ForkChoiceChecks) {
+	if checks.Time != nil {
+		require.EqualValues(t, *checks.Time, store.Time(), "step %d: time", index)
+	}
+	if checks.GenesisTime != nil {
+		require.EqualValues(t, *checks.GenesisTime, store.GenesisTime(), "step %d: genesis time", index)
+	}
+	if checks.Head != nil {
+		head, err := store.GetHead()
+		require.NoError(t, err, "step %d", index)
+		require.EqualValues(t, decodeRoot(t, checks.Head.Root), head, "step %d: head root", index)
+		require.EqualValues(t, checks.Head.Slot, store.Block(head).Slot, "step %d: head slot", index)
+	}
+	if checks.JustifiedCheckpoint != nil {
+		requireCheckpoint(t, index, checks.JustifiedCheckpoint, store.JustifiedCheckpoint())
+	}
+	if checks.BestJustifiedCheckpoint != nil {
+		requireCheckpoint(t, index, checks.BestJustifiedCheckpoint, store.BestJustifiedCheckpoint())
+	}
+	if checks.FinalizedCheckpoint != nil {
+		requireCheckpoint(t, index, checks.FinalizedCheckpoint, store.FinalizedCheckpoint())
+	}
+	if checks.ProposerBoostRoot != nil {
+		expected := decodeRoot(t, *checks.ProposerBoostRoot)
+		actual := store.ProposerBoostRoot()
+		if actual == nil {
+			actual = make([]byte, 32)
+		}
+		require.EqualValues(t, expected, actual, "step %d: proposer boost root", index)
+	}
+}
+
+func requireCheckpoint(t *testing.T, index int, expected *ForkChoiceCheckpoint, actual *core.Checkpoint) {
+	require.EqualValues(t, expected.Epoch, actual.Epoch, "step %d: checkpoint epoch", index)
+	require.EqualValues(t, decodeRoot(t, expected.Root), actual.Root, "step %d: checkpoint root", index)
+}
+
+// reads the snappy compressed <name>.ssz_snappy from the case dir and decodes it with the ssz_static object of the given type
+func loadForkChoiceObject(t *testing.T, dir string, name string, typeName string) interface{} {
+	objFunc, ok := nameToObject[typeName]
+	require.True(t, ok && objFunc != nil, "no object function for %s", typeName)
+
+	compressed, err := ioutil.ReadFile(path.Join(dir, name+".ssz_snappy"))
+	require.NoError(t, err)
+	byts, err := snappy.Decode(nil, compressed)
+	require.NoError(t, err)
+	obj := objFunc()
+	require.NoError(t, obj.(ssz.Unmarshaler).UnmarshalSSZ(byts))
+	return obj
+}
+
+func decodeRoot(t *testing.T, root string) []byte {
+	ret, err := hex.DecodeString(root[2:])
+	require.NoError(t, err)
+	return ret
+}
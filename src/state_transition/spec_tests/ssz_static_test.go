@@ -13,7 +13,7 @@ import (
 )
 
 var nameToObject = map[string]func() interface{} {
-	"AggregateAndProof": nil,
+	"AggregateAndProof": func() interface{} { return new(core.AggregateAndProof) },
 	"Attestation": func() interface{} { return new(core.Attestation) },
 	"attestation": func() interface{} { return new(core.Attestation) },
 	"AttestationData": func() interface{} { return new(core.AttestationData) },
@@ -39,7 +39,7 @@ var nameToObject = map[string]func() interface{} {
 	"PendingAttestation": func() interface{} { return new(core.PendingAttestation) },
 	"ProposerSlashing": func() interface{} { return new(core.ProposerSlashing) },
 	"proposer_slashing": func() interface{} { return new(core.ProposerSlashing) },
-	"SignedAggregateAndProof": nil,
+	"SignedAggregateAndProof": func() interface{} { return new(core.SignedAggregateAndProof) },
 	"SignedBeaconBlock": func() interface{} { return new(core.SignedBlock) },
 	"SignedBeaconBlockHeader": func() interface{} { return new(core.SignedBlockHeader) },
 	"SignedVoluntaryExit": func() interface{} { return new(core.SignedVoluntaryExit) },
@@ -5,8 +5,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/eth1"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/shared/params"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
@@ -51,6 +53,10 @@ func initBlockHeader() (*core.BlockHeader, error) {
 
 type StateTestContext struct {
 	State *core.State
+	// eth1 chain the blocks vote on
+	Eth1DataSource eth1.DataSource
+	// holds the keys of the genesis validators
+	Signer *signer.LocalSigner
 }
 
 func NewStateTestContext(config *core.ChainConfig, eth1Data *core.ETH1Data, genesisTime uint64) *StateTestContext {
@@ -126,6 +132,12 @@ func NewStateTestContext(config *core.ChainConfig, eth1Data *core.ETH1Data, gene
 			Balances: 					 []uint64{},
 			Slashings:                   make([]uint64, params.ChainConfig.EpochsPerSlashingVector),
 		},
+		// no eth1 chain, blocks vote for the state's eth1 data
+		Eth1DataSource: &eth1.InMemoryDataSource{},
+	}
+	ret.Signer, err = signer.NewLocalSigner()
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	end := time.Now()
@@ -147,8 +159,10 @@ func (c *StateTestContext) PopulateGenesisValidator(validatorIndexEnd uint64) *S
 	var leaves [][]byte
 	deposits := make([]*core.Deposit, validatorIndexEnd)
 	for i := uint64(0) ; i < validatorIndexEnd ; i++ {
-		sk := &bls.SecretKey{}
-		sk.SetHexString(hex.EncodeToString([]byte(fmt.Sprintf("%d", uint64(i)))))
+		pubKey, err := c.Signer.AddKey([]byte(fmt.Sprintf("%d", uint64(i))))
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		cred, err := ssz.HashTreeRoot([]byte("test_withdrawal_cred"))
 		if err != nil {
@@ -156,11 +170,11 @@ func (c *StateTestContext) PopulateGenesisValidator(validatorIndexEnd uint64) *S
 		}
 
 		depositMessage := &core.DepositMessage{
-			PublicKey:             sk.GetPublicKey().Serialize(),
+			PublicKey:             pubKey,
 			WithdrawalCredentials: cred[:],
 			Amount:                32 * 1e9, // gwei
 		}
-		root, err := ssz.HashTreeRoot(depositMessage)
+		sig, err := c.Signer.SignDeposit(depositMessage)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -170,13 +184,13 @@ func (c *StateTestContext) PopulateGenesisValidator(validatorIndexEnd uint64) *S
 			PublicKey:             depositMessage.PublicKey,
 			WithdrawalCredentials: depositMessage.WithdrawalCredentials,
 			Amount:                depositMessage.Amount,
-			Signature:             sk.SignByte(root[:]).Serialize(),
+			Signature:             sig,
 		}
 		deposits[i] = &core.Deposit{
 			Proof:                nil,
 			Data:                 depositData,
 		}
-		root, err = ssz.HashTreeRoot(depositData)
+		root, err := ssz.HashTreeRoot(depositData)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -226,14 +240,14 @@ func (c *StateTestContext) PopulateGenesisValidator(validatorIndexEnd uint64) *S
 	return c
 }
 
-// will generate and save blocks from slot 0 until maxBlocks
+// will generate and save blocks from slot 1 until maxBlocks, the genesis block is at slot 0
 func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch uint64, finalizedEpoch uint64) *StateTestContext {
 	var previousBlockHeader *core.BlockHeader
 	previousBlockHeader, err := initBlockHeader()
 	if err != nil {
 		log.Fatal(err)
 	}
-	for i := 0 ; i < maxBlocks ; i++ {
+	for i := 1 ; i <= maxBlocks ; i++ {
 		log.Printf("progressing block %d\n", i)
 
 		start := time.Now()
@@ -245,31 +259,27 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 
 
 		// we increment it before we gt proposer as this is what happens in real block processing
-		// in slot > 0 the process slots is called before process block which increments slot by 1
-		if i != 0 {
-			stateCopy.Slot++
-		}
+		// the process slots is called before process block which increments slot by 1
+		stateCopy.Slot++
 		pID, err := shared.GetBlockProposerIndex(stateCopy)
 		if err != nil {
 			log.Fatal(err)
 		}
-		sk := []byte(fmt.Sprintf("%d", pID))
+		pubKey := shared.GetValidator(stateCopy, pID).PublicKey
 
 		// randao
-		randaoReveal, err := signRandao(c.State, sk)
+		randaoReveal, err := signRandao(c.State, c.Signer, pubKey)
 		if err != nil {
 			log.Fatal(err)
 		}
 
 		// parent
 		// replicates what the next process slot does
-		if i != 0 {
-			stateRoot,err := c.State.HashTreeRoot()
-			if err != nil {
-				log.Fatal(err)
-			}
-			previousBlockHeader.StateRoot =  stateRoot[:]
+		stateRoot,err := c.State.HashTreeRoot()
+		if err != nil {
+			log.Fatal(err)
 		}
+		previousBlockHeader.StateRoot =  stateRoot[:]
 		parentRoot,err := previousBlockHeader.HashTreeRoot()
 		if err != nil {
 			log.Fatal(err)
@@ -278,7 +288,7 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 		pre := time.Now()
 		log.Printf("pre: %f\n", pre.Sub(start).Seconds())
 
-		eth1Vote, err := defaultEth1Data() // TODO - block eth1 vote dynamic?
+		eth1Vote, err := eth1.GetEth1Vote(stateCopy, c.Eth1DataSource)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -289,12 +299,13 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 			ParentRoot:           parentRoot[:],
 			StateRoot:            params.ChainConfig.ZeroHash,
 			Body:                 &core.BlockBody{
-				RandaoReveal:         randaoReveal.Serialize(),
+				RandaoReveal:         randaoReveal,
 				Attestations:         []*core.Attestation{},
 				Eth1Data: 			  eth1Vote,
+				Graffiti:             make([]byte, 32),
 			},
 		}
-		populateAttestations(c.State, block, uint64(i), justifiedEpoch, finalizedEpoch, block.ParentRoot)
+		populateAttestations(c.State, c.Signer, block, uint64(i), justifiedEpoch, finalizedEpoch, block.ParentRoot)
 
 		att := time.Now()
 		log.Printf("att: %f\n", att.Sub(pre).Seconds())
@@ -319,11 +330,7 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 		log.Printf("compu: %f\n", compu.Sub(att).Seconds())
 
 		// sign
-		blockDomain, err := shared.GetDomain(c.State, params.ChainConfig.DomainBeaconProposer, shared.GetCurrentEpoch(c.State))
-		if err != nil {
-			log.Fatal(err)
-		}
-		sig, err := shared.SignBlock(block, sk, blockDomain) // TODO - dynamic domain
+		sig, err := c.Signer.SignBlock(c.State, block, pubKey)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -334,7 +341,7 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 		// execute
 		newState, err := st.ExecuteStateTransition(c.State, &core.SignedBlock{
 			Block:                block,
-			Signature:            sig.Serialize(),
+			Signature:            sig,
 		}, true)
 		if err != nil {
 			log.Fatal(err)
@@ -362,7 +369,7 @@ func (c *StateTestContext) ProgressSlotsAndEpochs(maxBlocks int, justifiedEpoch
 	return c
 }
 
-func populateAttestations(state *core.State, block *core.Block, slot uint64, justifiedEpoch uint64, finalizedEpoch uint64, headRoot []byte) {
+func populateAttestations(state *core.State, keySigner signer.Signer, block *core.Block, slot uint64, justifiedEpoch uint64, finalizedEpoch uint64, headRoot []byte) {
 	if slot == 0 { // TODO - attestations at slot 0?
 		return // start from slot 1 forward
 	}
@@ -397,29 +404,28 @@ func populateAttestations(state *core.State, block *core.Block, slot uint64, jus
 		}
 		deepcopier.Copy(state.CurrentJustifiedCheckpoint).To(data.Source)
 
-		// root
-		root, err := ssz.HashTreeRoot(data)
-		if err != nil {
-			log.Fatalf("populateAttestations: %s", err.Error())
-		}
-
 		// sign
 		indices, err := shared.GetBeaconCommittee(nextStateCopy, slot-1, i)
 		if err != nil {
 			log.Fatalf("populateAttestations: %s", err.Error())
 		}
 		var aggregatedSig *bls.Sign
-		aggBits := make(bitfield.Bitlist, len(indices)) // for bytes
+		aggBits := bitfield.NewBitlist(uint64(len(indices)))
 		signed := uint64(0)
 		for aggIndex, index := range indices {
-			sk := &bls.SecretKey{}
-			sk.SetHexString(hex.EncodeToString([]byte(fmt.Sprintf("%d", index))))
-
 			// sign
+			sigByts, err := keySigner.SignAttestationData(nextStateCopy, data, shared.GetValidator(nextStateCopy, index).PublicKey)
+			if err != nil {
+				log.Fatalf("populateAttestations: %s", err.Error())
+			}
+			sig := &bls.Sign{}
+			if err := sig.Deserialize(sigByts); err != nil {
+				log.Fatalf("populateAttestations: %s", err.Error())
+			}
 			if aggregatedSig == nil {
-				aggregatedSig = sk.SignByte(root[:])
+				aggregatedSig = sig
 			} else {
-				aggregatedSig.Add(sk.SignByte(root[:]))
+				aggregatedSig.Add(sig)
 			}
 			aggBits.SetBitAt(uint64(aggIndex), true)
 			signed ++
@@ -445,15 +451,11 @@ func populateAttestations(state *core.State, block *core.Block, slot uint64, jus
 	}
 }
 
-func signRandao(state *core.State, sk []byte) (*bls.Sign, error) {
+func signRandao(state *core.State, keySigner signer.Signer, pubKey []byte) ([]byte, error) {
 	// We bump the slot by one to accurately calculate the epoch as when this
 	// randao reveal will be verified the state.Slot will be +1
 	copyState := shared.CopyState(state)
 	copyState.Slot++
 
-	data, domain, err := RANDAOSigningData(copyState)
-	if err != nil {
-		return nil, err
-	}
-	return shared.SignRandao(data, domain, sk)
+	return keySigner.SignRandaoReveal(copyState, shared.GetCurrentEpoch(copyState), pubKey)
 }
\ No newline at end of file
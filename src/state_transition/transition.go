@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/core"
 	"github.com/bloxapp/go-casper-ghost-SDK/src/shared"
+	"github.com/bloxapp/go-casper-ghost-SDK/src/signer"
 )
 
 type IStateTransition interface {
@@ -52,6 +53,10 @@ type IStateTransition interface {
 	//        state.slot += 1
 	//    ]
 	ProcessSlots(state *core.State, slot uint64) error
+	// BuildBlock returns a signed block for slot on top of state, body is applied to a copy of state advanced
+	// to slot (without signature verification) and the block's state root is set to the resulting state root.
+	// The block is signed by keySigner with the proposer's key and passes ExecuteStateTransition with validateResult set.
+	BuildBlock(state *core.State, slot uint64, body *core.BlockBody, keySigner signer.Signer) (*core.SignedBlock, error)
 }
 
 type StateTransition struct {}